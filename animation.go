@@ -0,0 +1,196 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+// Easing convert time progress (0 to 1) to animation progress.
+type Easing func(float64) float64
+
+// EaseLinear animate at constant speed.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad start slowly.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad end slowly.
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad start and end slowly.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+
+	return -1 + (4-2*t)*t
+}
+
+// EaseOutCubic end slowly, faster than EaseOutQuad at start.
+func EaseOutCubic(t float64) float64 {
+	t--
+
+	return t*t*t + 1
+}
+
+// OnAnimate is call at each frame with animation progress (0 to 1).
+type OnAnimate func(*Animation, float64)
+
+// OnAnimationDone is call when animation is finished.
+type OnAnimationDone func(*Animation)
+
+// Animation change something over a duration. Callbacks are call by
+// application on UI goroutine, once per frame.
+type Animation struct {
+	// Duration of animation.
+	Duration time.Duration
+	// Easing function. If nil, EaseLinear.
+	Easing Easing
+	// Call at each frame.
+	OnAnimate OnAnimate
+	// Call when animation is finished. Not call if animation is stopped.
+	OnDone OnAnimationDone
+
+	start time.Time
+	// Incremented at each start, to know if a callback restarts animation.
+	starts  int
+	running bool
+}
+
+// Running return true if animation is started and not finished.
+func (a *Animation) Running() bool {
+	return a.running
+}
+
+// Progress of animation at time `now`, with easing. Return true when
+// animation is finished.
+func (a *Animation) progress(now time.Time) (float64, bool) {
+	t := 1.0
+
+	if a.Duration > 0 {
+		t = math.Min(float64(now.Sub(a.start))/float64(a.Duration), 1)
+	}
+
+	easing := a.Easing
+
+	if easing == nil {
+		easing = EaseLinear
+	}
+
+	return easing(math.Max(t, 0)), t >= 1
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewAnimation create new animation.
+func NewAnimation(duration time.Duration, easing Easing, onAnimate OnAnimate) *Animation {
+	return &Animation{
+		Duration:  duration,
+		Easing:    easing,
+		OnAnimate: onAnimate,
+	}
+}
+
+// NewBoundsAnimation move and resize view from its current bounds to `to`.
+// WmChangeBounds is sent only when bounds change.
+func NewBoundsAnimation(v TView, to Rect, duration time.Duration, easing Easing) *Animation {
+	from := v.GetBounds()
+	last := from
+
+	return NewAnimation(duration, easing, func(a *Animation, p float64) {
+		r := Rect{
+			X:      interpolate(from.X, to.X, p),
+			Y:      interpolate(from.Y, to.Y, p),
+			Width:  interpolate(from.Width, to.Width, p),
+			Height: interpolate(from.Height, to.Height, p),
+		}
+
+		if r != last {
+			last = r
+			v.GetMessageBus().Send(BuildChangeBoundsMessage(v.Handler(), r))
+		}
+	})
+}
+
+// NewColorAnimation change text and background colors of view from its
+// current colors. Colors without RGB value (e.g. ColorDefault) change at end.
+func NewColorAnimation(v TView, foreground tcell.Color, background tcell.Color,
+	duration time.Duration, easing Easing) *Animation {
+	fromForeground := v.GetForegroundColor()
+	fromBackground := v.GetBackgroundColor()
+
+	return NewAnimation(duration, easing, func(a *Animation, p float64) {
+		v.SetForegroundColor(interpolateColor(fromForeground, foreground, p))
+		v.SetBackgroundColor(interpolateColor(fromBackground, background, p))
+		v.Invalidate()
+	})
+}
+
+// NewScrollAnimation scroll view from (fromX, fromY) to (toX, toY) with
+// WmScroll message.
+func NewScrollAnimation(v TComponent, fromX, fromY, toX, toY int,
+	duration time.Duration, easing Easing) *Animation {
+	lastX, lastY := fromX, fromY
+
+	return NewAnimation(duration, easing, func(a *Animation, p float64) {
+		x := interpolate(fromX, toX, p)
+		y := interpolate(fromY, toY, p)
+
+		if x != lastX || y != lastY {
+			lastX, lastY = x, y
+			v.GetMessageBus().Send(BuildScrollMessage(v.Handler(), x, y))
+		}
+	})
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func interpolate(from, to int, p float64) int {
+	return from + int(math.Round(float64(to-from)*p))
+}
+
+func interpolateColor(from, to tcell.Color, p float64) tcell.Color {
+	if p >= 1 {
+		return to
+	}
+
+	if from == tcell.ColorDefault || to == tcell.ColorDefault {
+		return from
+	}
+
+	r1, g1, b1 := from.RGB()
+	r2, g2, b2 := to.RGB()
+
+	if r1 < 0 || r2 < 0 {
+		return from
+	}
+
+	return tcell.NewRGBColor(
+		int32(interpolate(int(r1), int(r2), p)),
+		int32(interpolate(int(g1), int(g2), p)),
+		int32(interpolate(int(b1), int(b2), p)))
+}
@@ -0,0 +1,133 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestAnimation_easing(t *testing.T) {
+	easings := map[string]Easing{
+		"linear":    EaseLinear,
+		"inQuad":    EaseInQuad,
+		"outQuad":   EaseOutQuad,
+		"inOutQuad": EaseInOutQuad,
+		"outCubic":  EaseOutCubic,
+	}
+
+	for name, easing := range easings {
+		if easing(0) != 0 || math.Abs(easing(1)-1) > 1e-9 {
+			t.Errorf("Easing %s must start at 0 and end at 1", name)
+		}
+	}
+
+	if EaseInQuad(0.5) >= 0.5 || EaseOutQuad(0.5) <= 0.5 || EaseInOutQuad(0.5) != 0.5 {
+		t.Error("Wrong easing at half time")
+	}
+}
+
+func TestAnimation_progress(t *testing.T) {
+	start := time.Now()
+
+	a := NewAnimation(100*time.Millisecond, EaseInQuad, nil)
+	a.start = start
+
+	if p, done := a.progress(start.Add(50 * time.Millisecond)); p != 0.25 || done {
+		t.Errorf("Progress must be 0.25 at half time. Found %f", p)
+	}
+
+	if p, done := a.progress(start.Add(time.Second)); p != 1 || !done {
+		t.Errorf("Progress must be 1 after end. Found %f", p)
+	}
+
+	a.Duration = 0
+
+	if _, done := a.progress(start); !done {
+		t.Error("Animation without duration must be done at first frame")
+	}
+}
+
+func TestAnimation_NewBoundsAnimation(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	v := NewView("view", appConfig.Message, NewMemoryCanvas(10, 10))
+	v.SetBounds(Rect{X: 0, Y: 0, Width: 2, Height: 2})
+
+	a := NewBoundsAnimation(&v, Rect{X: 10, Y: 4, Width: 2, Height: 6}, time.Second, nil)
+
+	a.OnAnimate(a, 0.5)
+	a.OnAnimate(a, 0.5)
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != WmChangeBounds || msg.Value.(Rect) != (Rect{X: 5, Y: 2, Width: 2, Height: 4}) {
+		t.Errorf("Wrong bounds at half time. Found %+v", msg)
+	}
+
+	if len(*appConfig.Message.Channel()) != 0 {
+		t.Error("Same bounds must be sent once")
+	}
+}
+
+func TestAnimation_NewColorAnimation(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	v := NewView("view", appConfig.Message, NewMemoryCanvas(10, 10))
+	v.SetForegroundColor(tcell.ColorDefault)
+	v.SetBackgroundColor(tcell.NewRGBColor(0, 0, 0))
+
+	a := NewColorAnimation(&v, tcell.ColorWhite, tcell.NewRGBColor(100, 200, 50), time.Second, nil)
+
+	a.OnAnimate(a, 0.5)
+
+	if v.GetBackgroundColor() != tcell.NewRGBColor(50, 100, 25) {
+		t.Errorf("Wrong background at half time. Found %06x", v.GetBackgroundColor().Hex())
+	}
+
+	if v.GetForegroundColor() != tcell.ColorDefault {
+		t.Error("Color without RGB must change at end")
+	}
+
+	a.OnAnimate(a, 1)
+
+	if v.GetForegroundColor() != tcell.ColorWhite {
+		t.Error("Color must be set at end")
+	}
+}
+
+func TestAnimation_NewScrollAnimation(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	v := NewView("view", appConfig.Message, NewMemoryCanvas(10, 10))
+
+	a := NewScrollAnimation(&v, 0, 10, 0, 0, time.Second, nil)
+
+	a.OnAnimate(a, 0)
+	a.OnAnimate(a, 0.2)
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != WmScroll || msg.Handler != v.Handler() || msg.Value.(Rect).Y != 8 {
+		t.Errorf("Wrong scroll message. Found %+v", msg)
+	}
+
+	if len(*appConfig.Message.Channel()) != 0 {
+		t.Error("Scroll message must be sent only when position change")
+	}
+}
@@ -17,11 +17,16 @@ package base
 import (
 	"container/list"
 	"errors"
+	"time"
 
 	"github.com/gdamore/tcell"
 	"github.com/google/uuid"
 )
 
+// DefaultDoubleClickInterval is maximum time between two clicks of a double
+// click.
+const DefaultDoubleClickInterval = 500 * time.Millisecond
+
 type lastCursorPosAndStyle struct {
 	x     int
 	y     int
@@ -34,14 +39,41 @@ type lastCursorPosAndStyle struct {
 type Application struct {
 	// Main window.
 	mainWindow TView
+	// Desktop under all windows.
+	desktop *Desktop
 	// Remember last windows under cursor to sent mouse move, enter, leave.
 	lastWindowUnderMouse TView
+	// Same for child view of window.
+	lastViewUnderMouse TView
+	// View that received button down. Receive mouse drag until button up.
+	mouseCapture TView
+	// View that shows text cursor.
+	cursorOwner uuid.UUID
+	// Last left button down to detect double click.
+	lastClick      time.Time
+	lastClickEvent tcell.EventMouse
+	// Text of clipboard.
+	clipboard string
 	// Quit application on Ctrl+C.
 	ExitOnCtrlC bool
 	// Show text mouse cursor.
 	ShowMouseCursor bool
-	// Windows list. The First item is Windows that have focus.
+	// Enable window commands keys (Ctrl+F6 to cycle windows, Shift+F6 or
+	// Alt+Tab to go back to previous window).
+	WindowCommands bool
+	// Time between two animation frames. DefaultFrameInterval if not positive.
+	FrameInterval time.Duration
+	// Maximum time between two clicks of a double click.
+	DoubleClickInterval time.Duration
+	// Current time used by animations and double click. time.Now by default,
+	// can be replaced (e.g. in tests).
+	Clock func() time.Time
+	// Hotkey to export screen. Nil to disable.
+	ScreenExport *ScreenExportHotkey
+	// Windows list. The First item is the top window.
 	windowsList *list.List
+	// Activation history. The last item is window that have focus.
+	activationHistory []TView
 	// Message bus.
 	message Bus
 	// Canvas to draw.
@@ -50,6 +82,18 @@ type Application struct {
 	previousMousEvent tcell.EventMouse
 	// Last cursor.
 	lastCursorPosAndStyle lastCursorPosAndStyle
+	// Screen areas to repaint at next frame.
+	invalidRegions []Rect
+	// Theme of application. If nil, default theme.
+	theme *Theme
+	// Stylesheet of application. Can be nil.
+	stylesheet *Stylesheet
+	// Theme with stylesheet sent to views.
+	appliedTheme *Theme
+	// Running animations.
+	animations []*Animation
+	// Close to stop animation frames. Nil if no animation.
+	stopFrames chan bool
 }
 
 // MainWindow return main windows.
@@ -78,46 +122,72 @@ func (a *Application) Init() error {
 
 	a.canvas.screen.EnableMouse()
 
+	width, height := a.canvas.screen.Size()
+
+	a.Desktop().SetBounds(Rect{
+		X:      0,
+		Y:      0,
+		Width:  width,
+		Height: height,
+	})
+
 	return nil
 }
 
 // Run application and wait event.
 func (a *Application) Run() {
 	defer a.canvas.screen.Fini()
+	defer a.stopFrameTicker()
 
-	a.canvas.screen.Clear()
+	a.Start()
 
-	a.storeCursorInfo(0, 0)
+	go poolEvent(a.canvas.screen, a.message)
 
-	applicationHandler := ApplicationHandler()
+	for doContinue := true; doContinue; {
+		doContinue = a.manageMessage(<-*a.message.Channel())
 
-	var msg Message
-	doContinue := true
+		// Show frame only when all pending messages are managed.
+		if len(*a.message.Channel()) == 0 {
+			a.showFrame()
+		}
+	}
+}
+
+// Start prepare first frame and give focus to top window. Run call it. Call
+// it only to drive application with Step without event loop (e.g. test).
+func (a *Application) Start() {
+	a.canvas.screen.Clear()
+
+	a.storeCursorInfo(0, 0)
 
 	// First time send draw message to create screen.
-	a.message.Send(BuildDrawMessage(applicationHandler))
+	a.message.Send(BuildDrawMessage(ApplicationHandler()))
 
-	go poolEvent(a.canvas.screen, a.message)
+	a.sortWindowsByLayer()
 
-	a.windowsList.Front().Value.(TView).SetFocused(true)
+	// Popups (e.g. menu bar) are above windows but never active.
+	e := a.findPreviousActiveWindow(nil)
 
-	for doContinue {
-		msg = <-*a.message.Channel()
+	if e == nil {
+		e = a.windowsList.Front()
+	}
 
-		if msg.Type == WmKey && a.ExitOnCtrlC {
-			if msg.Value.(*tcell.EventKey).Key() == tcell.KeyCtrlC {
-				doContinue = false
-			} else {
-				a.callFocusedWindowHandleMessage(msg)
-			}
-		} else if msg.Handler == applicationHandler {
-			doContinue = a.manageMyMessage(msg)
-		} else {
-			a.callWindowHandleMessage(msg)
-		}
+	a.pushActivationHistory(e.Value.(TView))
+	a.ActiveWindow().SetFocused(true)
+}
 
-		a.canvas.screen.Sync()
+// Step manage all pending messages then show frame. Never wait event.
+// Return false if application must stop.
+func (a *Application) Step() bool {
+	for len(*a.message.Channel()) > 0 {
+		if !a.manageMessage(<-*a.message.Channel()) {
+			return false
+		}
 	}
+
+	a.showFrame()
+
+	return true
 }
 
 // WindowsList return the current windows list.
@@ -137,9 +207,23 @@ func (a *Application) Canvas() TCanvas {
 	return &a.canvas
 }
 
+// Desktop return the desktop view drawn under all windows.
+func (a *Application) Desktop() *Desktop {
+	if a.desktop == nil {
+		d := NewDesktop("desktop", a.message, a.Canvas())
+		d.SetTheme(a.currentTheme())
+
+		a.desktop = &d
+	}
+
+	return a.desktop
+}
+
 // AddWindow add window to list. If first window, she become the main window.
 func (a *Application) AddWindow(w TView) {
+	a.applyTheme(w)
 	a.windowsList.PushFront(w)
+	a.sortWindowsByLayer()
 
 	// TODO allow change main window
 	if a.mainWindow == nil {
@@ -153,6 +237,9 @@ func (a *Application) AddWindow(w TView) {
 type applicationCanvas struct {
 	brush  tcell.Style
 	screen tcell.Screen
+	// If clipping, only clip area can be draw.
+	clipping bool
+	clip     Rect
 }
 
 // SetBrush set the brush to draw.
@@ -171,26 +258,110 @@ func (a *applicationCanvas) CreateCanvasFrom(r Rect) TCanvas {
 
 // PrintChar print a charactere.
 func (a *applicationCanvas) PrintChar(x int, y int, char rune) {
-	a.screen.SetContent(x, y, char, nil, a.brush)
+	a.PrintCharWithBrush(x, y, char, a.brush)
 }
 
 // PrintCharWithBrush print a charactere with brush.
 func (a *applicationCanvas) PrintCharWithBrush(x int, y int, char rune, brush tcell.Style) {
-	a.screen.SetContent(x, y, char, nil, brush)
+	a.PrintCellWithBrush(x, y, char, nil, brush)
+}
+
+// PrintCellWithBrush print a charactere and his combining runes with brush.
+func (a *applicationCanvas) PrintCellWithBrush(x int, y int, char rune, combc []rune, brush tcell.Style) {
+	if a.isClipped(x, y) {
+		return
+	}
+
+	a.screen.SetContent(x, y, char, combc, brush)
+}
+
+// PrintString print a string with current brush. Return width in cells.
+func (a *applicationCanvas) PrintString(x int, y int, text string) int {
+	return printString(a, x, y, text, a.brush)
+}
+
+// PrintStringWithBrush print a string with brush. Return width in cells.
+func (a *applicationCanvas) PrintStringWithBrush(x int, y int, text string, brush tcell.Style) int {
+	return printString(a, x, y, text, brush)
+}
+
+// MeasureString return width in cells of string.
+func (a *applicationCanvas) MeasureString(text string) int {
+	return MeasureString(text)
+}
+
+// GetCell read cell on screen.
+func (a *applicationCanvas) GetCell(x int, y int) Cell {
+	mainc, combc, style, _ := a.screen.GetContent(x, y)
+
+	return Cell{
+		Char:      mainc,
+		Combining: combc,
+		Style:     style,
+	}
+}
+
+// DrawHLine draw horizontal line with current brush.
+func (a *applicationCanvas) DrawHLine(x int, y int, length int, style LineStyle) {
+	drawHLine(a, x, y, length, style, a.brush)
+}
+
+// DrawVLine draw vertical line with current brush.
+func (a *applicationCanvas) DrawVLine(x int, y int, length int, style LineStyle) {
+	drawVLine(a, x, y, length, style, a.brush)
+}
+
+// DrawRect draw rectangle border with current brush.
+func (a *applicationCanvas) DrawRect(r Rect, style LineStyle) {
+	drawRect(a, r, style, a.brush)
+}
+
+// DrawFrame draw rectangle border and fill inside with current brush.
+func (a *applicationCanvas) DrawFrame(r Rect, style LineStyle) {
+	drawFrame(a, r, style, a.brush)
 }
 
 // Fill fill canvas zone.
 func (a *applicationCanvas) Fill(bounds Rect) {
 	for y := bounds.Y; y < bounds.Y+bounds.Height; y++ {
 		for x := bounds.X; x < bounds.X+bounds.Width; x++ {
-			a.screen.SetContent(x, y, ' ', nil, a.brush)
+			a.PrintCharWithBrush(x, y, ' ', a.brush)
 		}
 	}
 }
 
+// Return true if cell is out of clip area.
+func (a *applicationCanvas) isClipped(x int, y int) bool {
+	return a.clipping && !(InHorizontal(x, a.clip) && InVertical(y, a.clip))
+}
+
 //------------------------------------------------------------------------------
 // Internal functions
 
+// Manage one message. Return false if application must stop.
+func (a *Application) manageMessage(msg Message) bool {
+	doContinue := true
+
+	if msg.Type == WmKey {
+		doContinue = a.manageKeyMessage(msg)
+	} else if msg.Handler == ApplicationHandler() {
+		doContinue = a.manageMyMessage(msg)
+	} else {
+		a.callWindowHandleMessage(msg)
+	}
+
+	// Active window can be hidden or disabled by message.
+	a.checkActiveWindow()
+
+	return doContinue
+}
+
+// Repaint invalid regions and show screen.
+func (a *Application) showFrame() {
+	a.repaintInvalidRegions()
+	a.canvas.screen.Show()
+}
+
 func (a *Application) storeCursorInfo(x, y int) {
 	a.lastCursorPosAndStyle.x = x
 	a.lastCursorPosAndStyle.y = y
@@ -210,21 +381,45 @@ func (a *Application) manageMyMessage(msg Message) bool {
 		for e := a.windowsList.Front(); e != nil; e = e.Next() {
 			a.message.Send(BuildDrawMessage(BroadcastHandler()))
 		}
+	case WmAnimationFrame:
+		a.animateFrame()
+	case WmInvalidate:
+		a.addInvalidRegion(msg.Value.(Rect))
+	case WmStylesheetChanged:
+		a.SetStylesheet(msg.Value.(*Stylesheet))
+	case WmShowCursor:
+		caret := msg.Value.(Caret)
+
+		a.cursorOwner = caret.Owner
+		a.canvas.screen.ShowCursor(caret.X, caret.Y)
+	case WmHideCursor:
+		if msg.Value.(uuid.UUID) == a.cursorOwner {
+			a.cursorOwner = uuid.Nil
+			a.canvas.screen.HideCursor()
+		}
+	case WmSetClipboard, WmGetClipboard:
+		a.manageClipboardMessage(msg)
 	case WmQuit:
 		return false
 	case WmCreate:
 		// Add window to list
+		a.applyTheme(msg.Value.(TView))
 		a.windowsList.PushFront(msg.Value)
+		a.sortWindowsByLayer()
 	case WmDestroy:
 		// Remove window to list and check is MainWindow
 		for e := a.windowsList.Front(); e != nil; e = e.Next() {
 			if msg.Value.(TComponent).Handler() == e.Value.(TComponent).Handler() {
+				// Repaint what was under window.
+				a.addInvalidRegion(PaintBounds(e.Value.(TView)))
 				a.windowsList.Remove(e)
 
 				if msg.Value == a.mainWindow {
 					return false
 				}
 
+				a.removeFromActivationHistory(e.Value.(TView))
+
 				return true
 			}
 		}
@@ -270,22 +465,26 @@ func (a *Application) manageMouseClickDown(ev *tcell.EventMouse, side uint) {
 
 	e, window := a.findWindowsByCoordinate(x, y)
 
+	a.closePopups(window, x, y)
+
 	if window == nil {
 		return
 	}
 
-	// Is windows has already focus ?
-	currentFocusedWindow := a.windowsList.Front().Value.(TView)
+	_, popup := window.(TPopup)
 
-	if window.Handler() == currentFocusedWindow.Handler() {
+	// Is windows has already focus ? Popup never takes focus.
+	if popup || (a.ActiveWindow() != nil && window.Handler() == a.ActiveWindow().Handler()) {
 		// Send a click message
 		a.message.Send(BuildClickMouseMessage(window.Handler(), ev, side))
+
+		if side == WmLButtonDown {
+			a.mouseCapture = ViewAt(window, x, y)
+			a.manageDoubleClick(window, ev)
+		}
 	} else {
 		// Send focus message
-		a.windowsList.MoveToFront(e)
-
-		a.message.Send(BuildDesactivateMessage(currentFocusedWindow.Handler()))
-		a.message.Send(BuildActivateMessage(window.Handler()))
+		a.activateWindow(a.ActiveWindow(), e)
 	}
 }
 
@@ -339,6 +538,7 @@ func (a *Application) manageMouseMessage(msg Message) {
 		checkMouseMove = false
 	} else if ev.Buttons()&tcell.Button1 == 0 && a.previousMousEvent.Buttons()&tcell.Button1 != 0 {
 		a.manageMouseClickUp(ev, WmLButtonUp)
+		a.mouseCapture = nil
 		checkMouseMove = false
 	}
 
@@ -354,8 +554,14 @@ func (a *Application) manageMouseMessage(msg Message) {
 
 	x, y := ev.Position()
 
+	if ev.Buttons()&(tcell.WheelUp|tcell.WheelDown|tcell.WheelLeft|tcell.WheelRight) != 0 {
+		a.manageMouseWheel(ev)
+	}
+
 	// Check mouse move only if not click message send
 	if checkMouseMove {
+		a.manageMouseDrag(ev)
+
 		_, window := a.findWindowsByCoordinate(x, y)
 
 		if window == nil {
@@ -382,16 +588,154 @@ func (a *Application) manageMouseMessage(msg Message) {
 		}
 	}
 
+	// Click can be done without move before (e.g. touch screen).
+	_, window := a.findWindowsByCoordinate(x, y)
+	a.manageViewUnderMouse(window, x, y)
+
 	a.displayMouseCursor(x, y)
 
 	a.previousMousEvent = *ev
 }
 
+// Send mouse enter and leave to child views of window.
+func (a *Application) manageViewUnderMouse(window TView, x, y int) {
+	var v TView
+
+	if window != nil {
+		if v = ViewAt(window, x, y); v != nil && v.Handler() == window.Handler() {
+			v = nil
+		}
+	}
+
+	last := a.lastViewUnderMouse
+
+	if last != nil && v != nil && last.Handler() == v.Handler() {
+		return
+	}
+
+	if last != nil {
+		a.message.Send(BuildMouseLeaveMessage(last.Handler()))
+	}
+
+	if v != nil {
+		a.message.Send(BuildMouseEnterMessage(v.Handler(), x, y))
+	}
+
+	a.lastViewUnderMouse = v
+}
+
+// Close popups when mouse is pressed outside of them, except on their owner.
+func (a *Application) closePopups(window TView, x, y int) {
+	var v TView
+
+	if window != nil {
+		v = ViewAt(window, x, y)
+	}
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		p, ok := e.Value.(TPopup)
+
+		// Click in popup or in one of its sub-popups (e.g. submenu).
+		if !ok || (window != nil && openedBy(window, e.Value.(TView))) {
+			continue
+		}
+
+		if owner := p.GetOwner(); v == nil || owner == nil || owner.Handler() != v.Handler() {
+			a.message.Send(BuildClosePopupMessage(e.Value.(TView).Handler()))
+		}
+	}
+}
+
+// Give key to popups that preview keys. Return true if key is managed.
+func (a *Application) previewKey(ev *tcell.EventKey) bool {
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		if p, ok := e.Value.(TKeyPreview); ok && e.Value.(TView).GetVisible() && p.PreviewKey(ev) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Send double click if left button down is near previous one (same position,
+// short time).
+func (a *Application) manageDoubleClick(window TView, ev *tcell.EventMouse) {
+	x, y := ev.Position()
+	px, py := a.lastClickEvent.Position()
+	now := a.Clock()
+
+	if x == px && y == py && now.Sub(a.lastClick) <= a.DoubleClickInterval {
+		a.message.Send(BuildClickMouseMessage(window.Handler(), ev, WmLButtonDblClick))
+
+		// Third click is not a double click.
+		a.lastClick = time.Time{}
+	} else {
+		a.lastClick = now
+		a.lastClickEvent = *ev
+	}
+}
+
+// Send mouse move with left button pressed to view that received button down.
+func (a *Application) manageMouseDrag(ev *tcell.EventMouse) {
+	x, y := ev.Position()
+	px, py := a.previousMousEvent.Position()
+
+	if a.mouseCapture != nil && ev.Buttons()&tcell.Button1 != 0 && (x != px || y != py) {
+		a.message.Send(BuildMouseDragMessage(a.mouseCapture.Handler(), ev))
+	}
+}
+
+// Send wheel event to deepest view under mouse.
+func (a *Application) manageMouseWheel(ev *tcell.EventMouse) {
+	x, y := ev.Position()
+
+	var v TView
+
+	if _, window := a.findWindowsByCoordinate(x, y); window != nil {
+		v = ViewAt(window, x, y)
+	} else {
+		v = ViewAt(a.Desktop(), x, y)
+	}
+
+	if v != nil {
+		a.message.Send(BuildMouseWheelMessage(v.Handler(), ev))
+	}
+}
+
+// Manage keyboard message. Return false to quit application.
+func (a *Application) manageKeyMessage(msg Message) bool {
+	ev := msg.Value.(*tcell.EventKey)
+
+	if a.manageScreenExportKey(ev) {
+		return true
+	}
+
+	if a.manageWindowCommandKey(ev) {
+		return true
+	}
+
+	if a.previewKey(ev) {
+		return true
+	}
+
+	if a.ExitOnCtrlC {
+		if ev.Key() == tcell.KeyCtrlC {
+			return false
+		}
+
+		a.callFocusedWindowHandleMessage(msg)
+	} else {
+		a.callWindowHandleMessage(msg)
+	}
+
+	return true
+}
+
 // Call focused windows if not nil.
 func (a *Application) callFocusedWindowHandleMessage(msg Message) {
-	w := a.windowsList.Front()
-
-	w.Value.(TView).HandleMessage(msg)
+	if w := a.ActiveWindow(); w != nil {
+		w.HandleMessage(msg)
+	}
 }
 
 // Call windows by handle.
@@ -399,14 +743,30 @@ func (a *Application) callWindowHandleMessage(msg Message) {
 	if msg.Handler == BroadcastHandler() {
 		var currentWindow TView
 
-		for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		// Desktop is under all windows, so it must be first to draw.
+		a.Desktop().HandleMessage(msg)
+
+		// Start from bottom window to draw top window at end.
+		for e := a.windowsList.Back(); e != nil; e = e.Prev() {
 			currentWindow = e.Value.(TView)
 			currentWindow.HandleMessage(msg)
 		}
 	} else {
-		_, w := a.findWindowsByHandle(msg.Handler)
+		if _, w := a.findWindowsByHandle(msg.Handler); w != nil {
+			w.HandleMessage(msg)
 
-		w.HandleMessage(msg)
+			return
+		}
+
+		// Maybe child of a window.
+		for e := a.windowsList.Front(); e != nil; e = e.Next() {
+			if e.Value.(TView).HandleMessage(msg) {
+				return
+			}
+		}
+
+		// Maybe desktop or one of desktop children.
+		a.Desktop().HandleMessage(msg)
 	}
 }
 
@@ -417,19 +777,10 @@ func poolEvent(screen tcell.Screen, message Bus) {
 
 		switch ev := ev.(type) {
 		case *tcell.EventMouse:
-			message.Send(Message{
-				Handler: ApplicationHandler(),
-				Type:    WmMouse,
-				Value:   ev,
-			})
+			message.Send(BuildMouseMessage(ev))
 
-			screen.Sync()
 		case *tcell.EventKey:
-			message.Send(Message{
-				Handler: BroadcastHandler(),
-				Type:    WmKey,
-				Value:   ev,
-			})
+			message.Send(BuildKeyMessage(ev))
 		case *tcell.EventResize:
 			screen.Sync()
 			message.Send(BuildScreenResizeMessage(screen))
@@ -450,9 +801,13 @@ func NewApplication(config ApplicationConfig) Application {
 	}
 
 	return Application{
-		ExitOnCtrlC: true,
-		windowsList: list.New(),
-		message:     config.Message,
-		canvas:      ac,
+		theme:               screenTheme(ac.brush, config.ScreenStyle),
+		ExitOnCtrlC:         true,
+		FrameInterval:       DefaultFrameInterval,
+		DoubleClickInterval: DefaultDoubleClickInterval,
+		Clock:               time.Now,
+		windowsList:         list.New(),
+		message:             config.Message,
+		canvas:              ac,
 	}
 }
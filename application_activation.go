@@ -0,0 +1,178 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"container/list"
+
+	"github.com/google/uuid"
+)
+
+// ActiveWindow return window that have focus or nil.
+func (a *Application) ActiveWindow() TView {
+	if len(a.activationHistory) == 0 {
+		return nil
+	}
+
+	return a.activationHistory[len(a.activationHistory)-1]
+}
+
+// ActivationHistory return top-level windows in activation order. The last
+// item is the active window.
+// Becarefull, each call create a new array to return.
+func (a *Application) ActivationHistory() []TView {
+	h := make([]TView, len(a.activationHistory))
+
+	copy(h, a.activationHistory)
+
+	return h
+}
+
+// PreviousWindow activate window that was active before current one (like
+// Alt+Tab).
+func (a *Application) PreviousWindow() {
+	active := a.ActiveWindow()
+
+	if e := a.findPreviousActiveWindow(active); e != nil {
+		a.activateWindow(active, e)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Move window `e` to front and send desactivate/activate messages.
+func (a *Application) activateWindow(previous TView, e *list.Element) {
+	a.sortWindowsByLayer()
+	a.moveToFrontOfLayer(e)
+
+	a.pushActivationHistory(e.Value.(TView))
+
+	// Window can be above other windows now.
+	a.addInvalidRegion(PaintBounds(e.Value.(TView)))
+
+	if previous != nil {
+		// Frame of previous window is drawn with inactive colors now.
+		a.addInvalidRegion(PaintBounds(previous))
+
+		if contains(previous, a.cursorOwner) {
+			a.message.Send(BuildHideCursorMessage(a.cursorOwner))
+		}
+
+		a.message.Send(BuildDesactivateMessage(previous.Handler()))
+	}
+
+	a.message.Send(BuildActivateMessage(e.Value.(TView).Handler()))
+}
+
+// Add window at top of activation history.
+func (a *Application) pushActivationHistory(w TView) {
+	a.removeFromHistory(w)
+
+	a.activationHistory = append(a.activationHistory, w)
+}
+
+// Remove window (e.g. destroyed) from activation history. If window was
+// active, previous window is activated.
+func (a *Application) removeFromActivationHistory(w TView) {
+	active := a.ActiveWindow()
+
+	a.removeFromHistory(w)
+
+	if active == nil || active.Handler() != w.Handler() {
+		return
+	}
+
+	e := a.findPreviousActiveWindow(nil)
+
+	if e == nil {
+		// No window can be activate, use top window like before.
+		e = a.windowsList.Front()
+	}
+
+	if e != nil {
+		a.activateWindow(nil, e)
+	}
+}
+
+func (a *Application) removeFromHistory(w TView) {
+	for i, h := range a.activationHistory {
+		if h.Handler() == w.Handler() {
+			a.activationHistory = append(a.activationHistory[:i], a.activationHistory[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// If active window is hidden or disabled, activate previous window.
+func (a *Application) checkActiveWindow() {
+	active := a.ActiveWindow()
+
+	if active == nil || canBeActivated(active) {
+		return
+	}
+
+	if e := a.findPreviousActiveWindow(active); e != nil {
+		a.activateWindow(active, e)
+	}
+}
+
+// Find previous window that can be activated. Search in activation history
+// first, then in windows list.
+func (a *Application) findPreviousActiveWindow(exclude TView) *list.Element {
+	for i := len(a.activationHistory) - 1; i >= 0; i-- {
+		w := a.activationHistory[i]
+
+		if exclude != nil && w.Handler() == exclude.Handler() {
+			continue
+		}
+
+		if e, _ := a.findWindowsByHandle(w.Handler()); e != nil && canBeActivated(w) {
+			return e
+		}
+	}
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		w := e.Value.(TView)
+
+		if (exclude == nil || w.Handler() != exclude.Handler()) && canBeActivated(w) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Return true if component or one of its descendants has handler `handler`.
+func contains(c TComponent, handler uuid.UUID) bool {
+	if c.Handler() == handler {
+		return true
+	}
+
+	for _, child := range c.Children() {
+		if contains(child, handler) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func canBeActivated(w TView) bool {
+	_, popup := w.(TPopup)
+
+	return w.GetVisible() && w.GetEnabled() && !popup
+}
@@ -0,0 +1,177 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"fmt"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+// Create 3 windows side by side. Activation order is window1, window2,
+// window3.
+func createActivationTestHarness(t *testing.T) (*govisiontest.Harness, []*base.View) {
+	h := govisiontest.NewHarness(t, 80, 25)
+
+	windows := addTestWindows(h, "window1", "window2", "window3")
+
+	for i, w := range windows {
+		w.SetBounds(base.Rect{X: i * 20, Y: 0, Width: 10, Height: 5})
+	}
+
+	h.Start()
+
+	for _, w := range windows {
+		h.ClickOn(w.Name())
+	}
+
+	return h, windows
+}
+
+func checkActiveWindow(h *govisiontest.Harness, w base.TView, t *testing.T) {
+	t.Helper()
+
+	if h.App.ActiveWindow() != w {
+		t.Errorf("Active window must be %s. Found %s", w.Name(), h.App.ActiveWindow().Name())
+	}
+}
+
+func TestApplicationActivation_history(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	history := h.App.ActivationHistory()
+
+	for i, w := range windows {
+		if history[i] != w {
+			t.Errorf("Wrong window in history at %d: %s", i, history[i].Name())
+		}
+	}
+
+	checkActiveWindow(h, windows[2], t)
+}
+
+func TestApplicationActivation_destroy_active_window(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	events := recordActivation(windows)
+
+	h.Send(base.Message{
+		Handler: base.ApplicationHandler(),
+		Type:    base.WmDestroy,
+		Value:   windows[2],
+	})
+
+	checkActiveWindow(h, windows[1], t)
+
+	if fmt.Sprint(*events) != "[window2+]" {
+		t.Errorf("Window2 must be activate. Found %v", *events)
+	}
+
+	if len(h.App.ActivationHistory()) != 2 {
+		t.Error("Destroyed window must be removed from history")
+	}
+}
+
+func TestApplicationActivation_hide_active_window(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	events := recordActivation(windows)
+
+	windows[2].SetVisible(false)
+	h.WaitIdle()
+
+	checkActiveWindow(h, windows[1], t)
+
+	if fmt.Sprint(*events) != "[window3- window2+]" {
+		t.Errorf("Window3 must be desactivate and window2 activate. Found %v", *events)
+	}
+}
+
+func TestApplicationActivation_disable_active_window_skip_disabled_window(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	events := recordActivation(windows)
+
+	for _, w := range windows[1:] {
+		h.Send(base.Message{
+			Handler: w.Handler(),
+			Type:    base.WmEnable,
+			Value:   false,
+		})
+	}
+
+	checkActiveWindow(h, windows[0], t)
+
+	if fmt.Sprint(*events) != "[window3- window1+]" {
+		t.Errorf("Window3 must be desactivate and window1 activate. Found %v", *events)
+	}
+
+	if h.App.WindowsList()[0] != windows[0] {
+		t.Errorf("Active window must be on top. Found %s", h.App.WindowsList()[0].Name())
+	}
+}
+
+func TestApplicationActivation_PreviousWindow(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	h.App.PreviousWindow()
+	h.WaitIdle()
+
+	checkActiveWindow(h, windows[1], t)
+
+	h.App.PreviousWindow()
+	h.WaitIdle()
+
+	checkActiveWindow(h, windows[2], t)
+}
+
+func TestApplicationActivation_desactivate_previous_window(t *testing.T) {
+	h, windows := createActivationTestHarness(t)
+	defer h.Close()
+
+	child := base.NewView("child", h.Config.Message, windows[2].ClientCanvas())
+	child.SetParent(windows[2])
+	windows[2].AddChild(&child)
+
+	h.Send(base.BuildShowCursorMessage(child.Handler(), 41, 1))
+
+	events := recordActivation(windows)
+
+	draws := 0
+	windows[2].SetOnDraw(func(base.TView) {
+		draws++
+	})
+
+	h.App.PreviousWindow()
+	h.WaitIdle()
+
+	if draws == 0 {
+		t.Error("Previous window must be repainted")
+	}
+
+	if x, y, _ := h.Screen.GetCursor(); x >= 0 || y >= 0 {
+		t.Errorf("Cursor of previous window must be hidden. Found (%d, %d)", x, y)
+	}
+
+	if fmt.Sprint(*events) != "[window3- window2+]" {
+		t.Errorf("Window3 must be desactivate and window2 activate. Found %v", *events)
+	}
+}
@@ -0,0 +1,124 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"time"
+)
+
+// DefaultFrameInterval is time between two animation frames (30 frames per
+// second).
+const DefaultFrameInterval = time.Second / 30
+
+// Animate start animation. Must be call on UI goroutine (e.g. in message
+// callback). Restart animation if already running.
+func (a *Application) Animate(anim *Animation) {
+	anim.start = a.Clock()
+	anim.starts++
+
+	if !anim.running {
+		anim.running = true
+		a.animations = append(a.animations, anim)
+	}
+
+	if a.stopFrames == nil {
+		a.stopFrames = make(chan bool)
+
+		interval := a.FrameInterval
+
+		// Ticker needs positive interval.
+		if interval <= 0 {
+			interval = DefaultFrameInterval
+		}
+
+		go frameTicker(interval, a.message, a.stopFrames)
+	}
+}
+
+// StopAnimation stop animation without call OnDone.
+func (a *Application) StopAnimation(anim *Animation) {
+	for i, current := range a.animations {
+		if current == anim {
+			anim.running = false
+			a.animations = append(a.animations[:i], a.animations[i+1:]...)
+
+			break
+		}
+	}
+
+	if len(a.animations) == 0 {
+		a.stopFrameTicker()
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Animate all running animations. Finished animations are removed.
+func (a *Application) animateFrame() {
+	now := a.Clock()
+
+	// Callbacks can start or stop animations.
+	animations := make([]*Animation, len(a.animations))
+	copy(animations, a.animations)
+
+	for _, anim := range animations {
+		if !anim.running {
+			continue
+		}
+
+		p, done := anim.progress(now)
+		starts := anim.starts
+
+		if anim.OnAnimate != nil {
+			anim.OnAnimate(anim, p)
+		}
+
+		// Animation restarted by OnAnimate is not finished.
+		if done && anim.running && anim.starts == starts {
+			a.StopAnimation(anim)
+
+			if anim.OnDone != nil {
+				anim.OnDone(anim)
+			}
+		}
+	}
+}
+
+func (a *Application) stopFrameTicker() {
+	if a.stopFrames != nil {
+		close(a.stopFrames)
+		a.stopFrames = nil
+	}
+}
+
+// Run in go function to send animation frame message.
+func frameTicker(interval time.Duration, message Bus, stop chan bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Application is late: frame must not take place of other
+			// messages in bus.
+			if len(*message.Channel()) == 0 {
+				message.Send(BuildAnimationFrameMessage())
+			}
+		case <-stop:
+			return
+		}
+	}
+}
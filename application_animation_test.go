@@ -0,0 +1,239 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"testing"
+	"time"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+// Frames are sent by test. Time change only when test moves it.
+func createAnimationTestHarness(t *testing.T) (*govisiontest.Harness, *time.Time) {
+	h := govisiontest.NewHarness(t, 20, 5)
+
+	addTestWindows(h, "window")
+
+	now := time.Now()
+
+	h.App.Clock = func() time.Time {
+		return now
+	}
+
+	h.App.FrameInterval = time.Hour
+
+	h.Start()
+
+	return h, &now
+}
+
+func sendAnimationFrame(h *govisiontest.Harness) {
+	h.Send(base.BuildAnimationFrameMessage())
+}
+
+// Wait frames sent by application until condition is true.
+func waitAnimationFrames(h *govisiontest.Harness, condition func() bool) bool {
+	for timeout := time.Now().Add(2 * time.Second); time.Now().Before(timeout); {
+		time.Sleep(time.Millisecond)
+		h.WaitIdle()
+
+		if condition() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestApplication_Animate(t *testing.T) {
+	h, now := createAnimationTestHarness(t)
+	defer h.Close()
+
+	var progress []float64
+	done := 0
+
+	a := base.NewAnimation(100*time.Millisecond, nil, func(a *base.Animation, p float64) {
+		progress = append(progress, p)
+	})
+	a.OnDone = func(*base.Animation) {
+		done++
+	}
+
+	h.App.Animate(a)
+
+	if !a.Running() {
+		t.Fatal("Animation must be running")
+	}
+
+	sendAnimationFrame(h)
+
+	*now = now.Add(40 * time.Millisecond)
+	sendAnimationFrame(h)
+
+	*now = now.Add(80 * time.Millisecond)
+	sendAnimationFrame(h)
+	sendAnimationFrame(h)
+
+	if len(progress) != 3 || progress[0] != 0 || progress[1] != 0.4 || progress[2] != 1 {
+		t.Errorf("Wrong progress %v", progress)
+	}
+
+	if done != 1 || a.Running() {
+		t.Error("Animation must be done once")
+	}
+}
+
+func TestApplication_StopAnimation(t *testing.T) {
+	h, _ := createAnimationTestHarness(t)
+	defer h.Close()
+
+	calls := 0
+
+	a := base.NewAnimation(time.Second, nil, func(a *base.Animation, p float64) {
+		calls++
+	})
+	a.OnDone = func(*base.Animation) {
+		t.Error("OnDone must not be call when animation is stopped")
+	}
+
+	b := base.NewAnimation(time.Second, nil, nil)
+
+	h.App.Animate(a)
+	h.App.Animate(b)
+	h.App.StopAnimation(a)
+
+	sendAnimationFrame(h)
+
+	if calls != 0 || a.Running() || !b.Running() {
+		t.Error("Stopped animation must not be animate")
+	}
+
+	h.App.StopAnimation(b)
+}
+
+func TestApplication_Animate_frames(t *testing.T) {
+	h, _ := createAnimationTestHarness(t)
+	defer h.Close()
+
+	h.App.Clock = time.Now
+	h.App.FrameInterval = time.Millisecond
+
+	calls := 0
+
+	a := base.NewAnimation(time.Hour, nil, func(a *base.Animation, p float64) {
+		calls++
+	})
+
+	h.App.Animate(a)
+
+	if !waitAnimationFrames(h, func() bool { return calls > 1 }) {
+		t.Fatal("No animation frame")
+	}
+
+	h.App.StopAnimation(a)
+	h.WaitIdle()
+
+	time.Sleep(20 * time.Millisecond)
+
+	// Last frame can be sent while ticker stops.
+	if len(*h.Config.Message.Channel()) > 1 {
+		t.Error("Frames must stop without animation")
+	}
+}
+
+func TestApplication_Animate_restart_in_callback(t *testing.T) {
+	h, now := createAnimationTestHarness(t)
+	defer h.Close()
+
+	restarted := false
+	done := 0
+
+	a := base.NewAnimation(100*time.Millisecond, nil, nil)
+	a.OnAnimate = func(a *base.Animation, p float64) {
+		if p == 1 && !restarted {
+			restarted = true
+			h.App.Animate(a)
+		}
+	}
+	a.OnDone = func(*base.Animation) {
+		done++
+	}
+
+	h.App.Animate(a)
+
+	*now = now.Add(time.Second)
+	sendAnimationFrame(h)
+
+	if done != 0 || !a.Running() {
+		t.Error("Animation restarted by OnAnimate must continue")
+	}
+
+	*now = now.Add(time.Second)
+	sendAnimationFrame(h)
+
+	if done != 1 || a.Running() {
+		t.Error("Restarted animation must be done once")
+	}
+}
+
+func TestApplication_Animate_invalid_frame_interval(t *testing.T) {
+	h, _ := createAnimationTestHarness(t)
+	defer h.Close()
+
+	h.App.FrameInterval = 0
+
+	calls := 0
+
+	a := base.NewAnimation(time.Second, nil, func(a *base.Animation, p float64) {
+		calls++
+	})
+
+	h.App.Animate(a)
+	defer h.App.StopAnimation(a)
+
+	if !waitAnimationFrames(h, func() bool { return calls > 0 }) {
+		t.Error("Animation frame must be sent with default interval")
+	}
+}
+
+func TestApplication_Animate_frames_wait_pending_messages(t *testing.T) {
+	h, _ := createAnimationTestHarness(t)
+	defer h.Close()
+
+	h.App.Clock = time.Now
+	h.App.FrameInterval = time.Millisecond
+
+	calls := 0
+
+	a := base.NewAnimation(time.Hour, nil, func(a *base.Animation, p float64) {
+		calls++
+	})
+
+	h.Config.Message.Send(base.BuildEmptyMessage())
+
+	h.App.Animate(a)
+	defer h.App.StopAnimation(a)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if l := len(*h.Config.Message.Channel()); l != 1 {
+		t.Errorf("Frames must not be sent while messages are pending. Found %d messages", l)
+	}
+
+	if !waitAnimationFrames(h, func() bool { return calls > 0 }) {
+		t.Error("Frames must be sent when messages are managed")
+	}
+}
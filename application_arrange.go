@@ -0,0 +1,238 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"container/list"
+
+	"github.com/gdamore/tcell"
+)
+
+// Minimum size of window when cascade or tile.
+const minimumArrangeWidth = 2
+const minimumArrangeHeight = 2
+
+// Cascade rearrange visible top-level windows. Each window is shifted one
+// column right and one row down from the previous one. When there is no more
+// place, shift restart from top left corner. Focused window is the last one
+// (on top).
+func (a *Application) Cascade() {
+	windows := a.arrangeableWindows()
+	count := len(windows)
+
+	if count == 0 {
+		return
+	}
+
+	area := a.arrangeArea()
+
+	// Number of shifts before restart from top left corner.
+	steps := MinInt(count, MinInt(area.Width-minimumArrangeWidth, area.Height-minimumArrangeHeight)+1)
+	steps = MaxInt(steps, 1)
+
+	width := MaxInt(area.Width-(steps-1), minimumArrangeWidth)
+	height := MaxInt(area.Height-(steps-1), minimumArrangeHeight)
+
+	// Start from window at bottom to finish with focused window.
+	for i := 0; i < count; i++ {
+		w := windows[count-1-i]
+
+		a.message.Send(BuildChangeBoundsMessage(w.Handler(), Rect{
+			X:      area.X + i%steps,
+			Y:      area.Y + i%steps,
+			Width:  width,
+			Height: height,
+		}))
+	}
+}
+
+// TileHorizontal rearrange visible top-level windows one above the other.
+// Focused window is at top. When there is too many windows, next windows
+// restart from top.
+func (a *Application) TileHorizontal() {
+	windows := a.arrangeableWindows()
+	count := len(windows)
+
+	if count == 0 {
+		return
+	}
+
+	area := a.arrangeArea()
+
+	for i, w := range windows {
+		y, height := tileSlot(area.Height, count, i, minimumArrangeHeight)
+
+		a.message.Send(BuildChangeBoundsMessage(w.Handler(), Rect{
+			X:      area.X,
+			Y:      area.Y + y,
+			Width:  area.Width,
+			Height: height,
+		}))
+	}
+}
+
+// TileVertical rearrange visible top-level windows side by side.
+// Focused window is at left. When there is too many windows, next windows
+// restart from left.
+func (a *Application) TileVertical() {
+	windows := a.arrangeableWindows()
+	count := len(windows)
+
+	if count == 0 {
+		return
+	}
+
+	area := a.arrangeArea()
+
+	for i, w := range windows {
+		x, width := tileSlot(area.Width, count, i, minimumArrangeWidth)
+
+		a.message.Send(BuildChangeBoundsMessage(w.Handler(), Rect{
+			X:      area.X + x,
+			Y:      area.Y,
+			Width:  width,
+			Height: area.Height,
+		}))
+	}
+}
+
+// NextWindow activate next top-level window that can be activated. Current focused window
+// go to the back of his layer.
+func (a *Application) NextWindow() {
+	if a.ActiveWindow() == nil || a.windowsList.Len() < 2 {
+		return
+	}
+
+	current, _ := a.findWindowsByHandle(a.ActiveWindow().Handler())
+
+	if current == nil {
+		return
+	}
+
+	next := a.findNextActiveWindow(current)
+
+	if next == nil {
+		return
+	}
+
+	a.activateWindow(a.ActiveWindow(), next)
+	a.SendToBack(current.Value.(TView))
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Return visible top-level windows except popups. First item is focused
+// window.
+func (a *Application) arrangeableWindows() []TView {
+	windows := make([]TView, 0)
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		if w := e.Value.(TView); w.GetVisible() && layerOf(w) != layerPopup {
+			windows = append(windows, w)
+		}
+	}
+
+	return windows
+}
+
+// Return desktop area without rows of bars (popups not opened by a view, like
+// menu bar) at top or bottom of desktop.
+func (a *Application) arrangeArea() Rect {
+	area := a.Desktop().GetBounds()
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		w := e.Value.(TView)
+
+		if popup, ok := w.(TPopup); !ok || popup.GetOwner() != nil || !w.GetVisible() {
+			continue
+		}
+
+		b := w.GetBounds()
+
+		if b.X > area.X || b.X+b.Width < area.X+area.Width || b.Height >= area.Height {
+			continue
+		}
+
+		switch {
+		case b.Y == area.Y:
+			area.Y += b.Height
+			area.Height -= b.Height
+		case b.Y+b.Height == area.Y+area.Height:
+			area.Height -= b.Height
+		}
+	}
+
+	return area
+}
+
+// Return position and size of `index` part when `size` is split in `count`
+// parts. Remainder is given to first parts. Parts are never smaller than
+// `minimum`: if `size` is too small, parts restart from position 0.
+func tileSlot(size int, count int, index int, minimum int) (int, int) {
+	slots := MaxInt(MinInt(count, size/minimum), 1)
+	index %= slots
+
+	s := size / slots
+	position := index*s + MinInt(index, size%slots)
+
+	if index < size%slots {
+		s++
+	}
+
+	return position, MaxInt(s, minimum)
+}
+
+// Return next window that can be activated after `current`. Search restart
+// from top of list when bottom is reached.
+func (a *Application) findNextActiveWindow(current *list.Element) *list.Element {
+	for e := current.Next(); e != nil; e = e.Next() {
+		if canBeActivated(e.Value.(TView)) {
+			return e
+		}
+	}
+
+	for e := a.windowsList.Front(); e != current; e = e.Next() {
+		if canBeActivated(e.Value.(TView)) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Manage window commands keys. Return true if key is used.
+func (a *Application) manageWindowCommandKey(ev *tcell.EventKey) bool {
+	if !a.WindowCommands {
+		return false
+	}
+
+	// Some terminals report Ctrl+F6 as F30.
+	if (ev.Key() == tcell.KeyF6 && ev.Modifiers()&tcell.ModCtrl != 0) || ev.Key() == tcell.KeyF30 {
+		a.NextWindow()
+
+		return true
+	}
+
+	// Some terminals report Shift+F6 as F18.
+	if (ev.Key() == tcell.KeyF6 && ev.Modifiers()&tcell.ModShift != 0) || ev.Key() == tcell.KeyF18 ||
+		(ev.Key() == tcell.KeyTab && ev.Modifiers()&tcell.ModAlt != 0) {
+		a.PreviousWindow()
+
+		return true
+	}
+
+	return false
+}
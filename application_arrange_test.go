@@ -0,0 +1,234 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"fmt"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+	"github.com/gdamore/tcell"
+)
+
+func createArrangeTestHarness(t *testing.T) (*govisiontest.Harness, []*base.View) {
+	h := govisiontest.NewHarness(t, 80, 25)
+
+	windows := addTestWindows(h, "window1", "window2", "hidden", "window3")
+	windows[2].SetVisible(false)
+
+	return h, windows
+}
+
+func checkBounds(w base.TView, r base.Rect, t *testing.T) {
+	t.Helper()
+
+	if b := w.GetBounds(); b != r {
+		t.Errorf("Wrong bounds for %s. Want %+v found %+v", w.Name(), r, b)
+	}
+}
+
+func TestApplicationArrange_Cascade(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+	h.App.Cascade()
+	h.WaitIdle()
+
+	checkBounds(windows[0], base.Rect{X: 0, Y: 0, Width: 78, Height: 23}, t)
+	checkBounds(windows[1], base.Rect{X: 1, Y: 1, Width: 78, Height: 23}, t)
+	checkBounds(windows[3], base.Rect{X: 2, Y: 2, Width: 78, Height: 23}, t)
+
+	// Hidden window is not arranged.
+	checkBounds(windows[2], base.Rect{}, t)
+}
+
+func TestApplicationArrange_TileHorizontal(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+	h.App.TileHorizontal()
+	h.WaitIdle()
+
+	checkBounds(windows[3], base.Rect{X: 0, Y: 0, Width: 80, Height: 9}, t)
+	checkBounds(windows[1], base.Rect{X: 0, Y: 9, Width: 80, Height: 8}, t)
+	checkBounds(windows[0], base.Rect{X: 0, Y: 17, Width: 80, Height: 8}, t)
+}
+
+func TestApplicationArrange_TileVertical(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+	h.App.TileVertical()
+	h.WaitIdle()
+
+	checkBounds(windows[3], base.Rect{X: 0, Y: 0, Width: 27, Height: 25}, t)
+	checkBounds(windows[1], base.Rect{X: 27, Y: 0, Width: 27, Height: 25}, t)
+	checkBounds(windows[0], base.Rect{X: 54, Y: 0, Width: 26, Height: 25}, t)
+}
+
+func TestApplicationArrange_NextWindow_with_Ctrl_F6(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	h.App.WindowCommands = true
+
+	h.Start()
+
+	events := recordActivation(windows)
+
+	h.Key(tcell.KeyF6, ' ', tcell.ModCtrl)
+
+	// Hidden window is skipped.
+	if h.App.WindowsList()[0] != windows[1] {
+		t.Errorf("Focused window must be %s. Found %s", windows[1].Name(), h.App.WindowsList()[0].Name())
+	}
+
+	if fmt.Sprint(*events) != "[window3- window2+]" {
+		t.Errorf("Window3 must be desactivate and window2 activate. Found %v", *events)
+	}
+
+	keys := 0
+	windows[1].SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		if msg.Type == base.WmKey {
+			keys++
+		}
+
+		return false
+	})
+
+	h.Key(tcell.KeyF6, ' ', tcell.ModNone)
+
+	if keys != 1 || h.App.ActiveWindow() != windows[1] {
+		t.Error("F6 must be sent to active window")
+	}
+}
+
+func TestApplicationArrange_NextWindow_skip_disabled_window(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	windows[1].SetEnabled(false)
+
+	h.Start()
+	h.App.NextWindow()
+	h.WaitIdle()
+
+	if h.App.ActiveWindow() != windows[0] {
+		t.Errorf("Active window must be %s. Found %s", windows[0].Name(), h.App.ActiveWindow().Name())
+	}
+}
+
+func addArrangeTestBar(h *govisiontest.Harness) *testPopup {
+	bar := &testPopup{
+		View: base.NewView("bar", h.Config.Message, h.App.Canvas()),
+	}
+	bar.SetEnabled(true)
+	bar.SetVisible(true)
+	bar.SetBounds(base.Rect{X: 0, Y: 0, Width: 80, Height: 1})
+
+	h.App.AddWindow(bar)
+
+	return bar
+}
+
+func TestApplicationArrange_TileHorizontal_under_bar(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	bar := addArrangeTestBar(h)
+
+	h.Start()
+	h.App.TileHorizontal()
+	h.WaitIdle()
+
+	checkBounds(windows[3], base.Rect{X: 0, Y: 1, Width: 80, Height: 8}, t)
+	checkBounds(windows[1], base.Rect{X: 0, Y: 9, Width: 80, Height: 8}, t)
+	checkBounds(windows[0], base.Rect{X: 0, Y: 17, Width: 80, Height: 8}, t)
+
+	// Bar is not arranged.
+	checkBounds(bar, base.Rect{X: 0, Y: 0, Width: 80, Height: 1}, t)
+}
+
+func TestApplicationArrange_NextWindow_skip_bar(t *testing.T) {
+	h, windows := createArrangeTestHarness(t)
+	defer h.Close()
+
+	bar := addArrangeTestBar(h)
+
+	h.Start()
+
+	for i := 0; i < 3; i++ {
+		h.App.NextWindow()
+		h.WaitIdle()
+
+		if h.App.ActiveWindow() == bar {
+			t.Fatal("Bar can't be activated")
+		}
+	}
+
+	if h.App.ActiveWindow() != windows[3] {
+		t.Errorf("Active window must be %s. Found %s", windows[3].Name(), h.App.ActiveWindow().Name())
+	}
+}
+
+// More windows than rows of desktop.
+func createManyWindowsTestHarness(t *testing.T) (*govisiontest.Harness, []*base.View) {
+	h := govisiontest.NewHarness(t, 20, 6)
+
+	names := make([]string, 8)
+
+	for i := range names {
+		names[i] = fmt.Sprintf("window%d", i+1)
+	}
+
+	return h, addTestWindows(h, names...)
+}
+
+func TestApplicationArrange_Cascade_many_windows(t *testing.T) {
+	h, windows := createManyWindowsTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+	h.App.Cascade()
+	h.WaitIdle()
+
+	// Window8 is focused and on top.
+	for i, offset := range []int{0, 1, 2, 3, 4, 0, 1, 2} {
+		checkBounds(windows[i], base.Rect{X: offset, Y: offset, Width: 16, Height: 2}, t)
+	}
+}
+
+func TestApplicationArrange_Tile_many_windows(t *testing.T) {
+	h, windows := createManyWindowsTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+	h.App.TileHorizontal()
+	h.WaitIdle()
+
+	for i, y := range []int{2, 0, 4, 2, 0, 4, 2, 0} {
+		checkBounds(windows[i], base.Rect{X: 0, Y: y, Width: 20, Height: 2}, t)
+	}
+
+	h.App.TileVertical()
+	h.WaitIdle()
+
+	for i, x := range []int{18, 16, 14, 12, 9, 6, 3, 0} {
+		checkBounds(windows[i], base.Rect{X: x, Y: 0, Width: 2 + i/4, Height: 6}, t)
+	}
+}
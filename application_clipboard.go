@@ -0,0 +1,41 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/google/uuid"
+)
+
+// Clipboard return text of application clipboard.
+func (a *Application) Clipboard() string {
+	return a.clipboard
+}
+
+// SetClipboard change text of application clipboard.
+func (a *Application) SetClipboard(text string) {
+	a.clipboard = text
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func (a *Application) manageClipboardMessage(msg Message) {
+	switch msg.Type {
+	case WmSetClipboard:
+		a.SetClipboard(msg.Value.(string))
+	case WmGetClipboard:
+		a.message.Send(BuildPasteMessage(msg.Value.(uuid.UUID), a.clipboard))
+	}
+}
@@ -0,0 +1,132 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/google/uuid"
+)
+
+func TestApplication_Clipboard(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	app.manageMessage(BuildSetClipboardMessage("hello"))
+
+	if app.Clipboard() != "hello" {
+		t.Errorf("Clipboard must be 'hello'. Found '%s'", app.Clipboard())
+	}
+
+	handler := uuid.New()
+
+	app.manageMessage(BuildGetClipboardMessage(handler))
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != WmPaste || msg.Handler != handler || msg.Value != "hello" {
+		t.Errorf("Application must answer paste message. Found %+v", msg)
+	}
+}
+
+func TestApplication_Cursor(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+	app.Init()
+
+	screen := appConfig.Screen.(tcell.SimulationScreen)
+	owner := uuid.New()
+
+	app.manageMessage(BuildShowCursorMessage(owner, 3, 4))
+
+	if x, y, _ := screen.GetCursor(); x != 3 || y != 4 {
+		t.Errorf("Cursor must be at (3, 4). Found (%d, %d)", x, y)
+	}
+
+	// Other view does not own cursor.
+	app.manageMessage(BuildHideCursorMessage(uuid.New()))
+
+	if x, y, _ := screen.GetCursor(); x != 3 || y != 4 {
+		t.Error("Cursor can be hidden only by owner")
+	}
+
+	app.manageMessage(BuildHideCursorMessage(owner))
+
+	// Hidden cursor is out of screen.
+	if x, y, _ := screen.GetCursor(); x != -1 || y != -1 {
+		t.Error("Cursor must be hidden")
+	}
+}
+
+func TestApplication_Mouse_drag(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	window := NewView("window", appConfig.Message, app.Canvas())
+	window.SetEnabled(true)
+	window.SetVisible(true)
+	window.SetBounds(Rect{X: 0, Y: 0, Width: 20, Height: 10})
+
+	child := NewView("child", appConfig.Message, window.ClientCanvas())
+	child.SetEnabled(true)
+	child.SetVisible(true)
+	child.SetBounds(Rect{X: 2, Y: 2, Width: 5, Height: 1})
+	child.SetParent(&window)
+	window.AddChild(&child)
+
+	app.AddWindow(&window)
+	app.Init()
+	app.Start()
+	app.Step()
+
+	drags := func() []Message {
+		var messages []Message
+
+		for len(*appConfig.Message.Channel()) > 0 {
+			if msg := <-*appConfig.Message.Channel(); msg.Type == WmMouseDrag {
+				messages = append(messages, msg)
+			}
+		}
+
+		return messages
+	}
+
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(3, 2, tcell.ButtonNone, tcell.ModNone)))
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(4, 2, tcell.ButtonNone, tcell.ModNone)))
+
+	if d := drags(); len(d) != 0 {
+		t.Errorf("Mouse move without button is not a drag. Found %+v", d)
+	}
+
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(3, 2, tcell.Button1, tcell.ModNone)))
+	// Outside of child.
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(12, 5, tcell.Button1, tcell.ModNone)))
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(12, 5, tcell.Button1, tcell.ModNone)))
+
+	if d := drags(); len(d) != 1 || d[0].Handler != child.Handler() {
+		t.Errorf("Child must receive one drag message. Found %+v", d)
+	}
+
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(12, 5, tcell.ButtonNone, tcell.ModNone)))
+	app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(13, 5, tcell.ButtonNone, tcell.ModNone)))
+
+	if d := drags(); len(d) != 0 {
+		t.Errorf("Drag stops when button is released. Found %+v", d)
+	}
+}
@@ -23,7 +23,8 @@ import (
 
 // ApplicationConfig is configuration of application.
 type ApplicationConfig struct {
-	// Default style screen application.
+	// Default style screen application. Also desktop style of default theme
+	// (see RoleDesktop) until theme is changed.
 	ScreenStyle ApplicationStyle
 	// Screen of application.
 	Screen tcell.Screen
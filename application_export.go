@@ -0,0 +1,96 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"io"
+
+	"github.com/gdamore/tcell"
+)
+
+// ScreenExportHotkey is key that export screen.
+type ScreenExportHotkey struct {
+	// Key to press. If tcell.KeyRune, Rune is checked.
+	Key  tcell.Key
+	Rune rune
+	// Modifiers must be exactly the same.
+	Modifiers tcell.ModMask
+	// Format of export.
+	Format ExportFormat
+	// Output return where write export (file...). If writer is io.Closer,
+	// it's closed after export. On error, export is skipped.
+	Output func() (io.Writer, error)
+}
+
+// ExportScreen write whole screen in format.
+func (a *Application) ExportScreen(w io.Writer, format ExportFormat) error {
+	width, height := a.canvas.screen.Size()
+
+	return a.ExportRegion(w, Rect{
+		X:      0,
+		Y:      0,
+		Width:  width,
+		Height: height,
+	}, format)
+}
+
+// ExportRegion write `r` area of screen in format. Mouse cursor is not
+// exported.
+func (a *Application) ExportRegion(w io.Writer, r Rect, format ExportFormat) error {
+	lines := readCells(&a.canvas, r)
+
+	if a.ShowMouseCursor {
+		cursor := a.lastCursorPosAndStyle
+		x := cursor.x - r.X
+		y := cursor.y - r.Y
+
+		if y >= 0 && y < len(lines) && x >= 0 && x < len(lines[y]) {
+			lines[y][x] = Cell{
+				Char:      cursor.mainc,
+				Combining: cursor.combc,
+				Style:     cursor.style,
+			}
+		}
+	}
+
+	return exportCells(w, lines, format)
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Return true if key is screen export hotkey.
+func (a *Application) manageScreenExportKey(ev *tcell.EventKey) bool {
+	h := a.ScreenExport
+
+	if h == nil || h.Output == nil || ev.Key() != h.Key || ev.Modifiers() != h.Modifiers ||
+		(h.Key == tcell.KeyRune && ev.Rune() != h.Rune) {
+		return false
+	}
+
+	w, e := h.Output()
+
+	if e != nil {
+		return true
+	}
+
+	// Export error cannot be reported, nobody listen.
+	_ = a.ExportScreen(w, h.Format)
+
+	if c, ok := w.(io.Closer); ok {
+		c.Close()
+	}
+
+	return true
+}
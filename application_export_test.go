@@ -0,0 +1,127 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func createExportApplication(t *testing.T) (Application, ApplicationConfig) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	v := NewView("view", appConfig.Message, app.Canvas())
+	v.SetVisible(true)
+	v.SetBounds(Rect{X: 0, Y: 0, Width: 3, Height: 1})
+
+	app.AddWindow(&v)
+
+	if e := app.Init(); e != nil {
+		t.Error("Cannot initialize screen")
+	}
+
+	appConfig.Screen.Clear()
+	app.Canvas().PrintString(0, 0, "abc")
+
+	return app, appConfig
+}
+
+func TestApplicationExport_ExportScreen(t *testing.T) {
+	app, appConfig := createExportApplication(t)
+
+	defer appConfig.Screen.Fini()
+
+	var b bytes.Buffer
+
+	if e := app.ExportScreen(&b, ExportText); e != nil {
+		t.Errorf("Export fail: %s", e)
+	}
+
+	lines := strings.Split(b.String(), "\n")
+
+	// 25 lines and last empty string after last \n.
+	if len(lines) != 26 || lines[0] != "abc"+strings.Repeat(" ", 77) {
+		t.Errorf("Wrong screen export. Found %d lines, first '%s'", len(lines), lines[0])
+	}
+}
+
+func TestApplicationExport_mouse_cursor_not_exported(t *testing.T) {
+	app, appConfig := createExportApplication(t)
+
+	defer appConfig.Screen.Fini()
+
+	app.ShowMouseCursor = true
+	app.storeCursorInfo(1, 0)
+	app.Canvas().PrintChar(1, 0, 'X')
+
+	var b bytes.Buffer
+
+	if e := app.ExportRegion(&b, Rect{X: 0, Y: 0, Width: 3, Height: 1}, ExportText); e != nil {
+		t.Errorf("Export fail: %s", e)
+	}
+
+	if b.String() != "abc\n" {
+		t.Errorf("Mouse cursor must not be exported. Found '%s'", b.String())
+	}
+}
+
+type exportOutput struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (o *exportOutput) Close() error {
+	o.closed = true
+
+	return nil
+}
+
+func TestApplicationExport_hotkey(t *testing.T) {
+	app, appConfig := createExportApplication(t)
+
+	defer appConfig.Screen.Fini()
+
+	output := &exportOutput{}
+
+	app.ScreenExport = &ScreenExportHotkey{
+		Key:       tcell.KeyRune,
+		Rune:      'e',
+		Modifiers: tcell.ModAlt,
+		Format:    ExportANSI,
+		Output: func() (io.Writer, error) {
+			return output, nil
+		},
+	}
+
+	// Not the hotkey.
+	app.manageKeyMessage(BuildKeyMessage(tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone)))
+
+	if output.Len() != 0 {
+		t.Error("Screen must not be exported without modifier")
+	}
+
+	if !app.manageKeyMessage(BuildKeyMessage(tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModAlt))) {
+		t.Error("Application must continue after export")
+	}
+
+	if !strings.HasPrefix(output.String(), "\x1b[") || !output.closed {
+		t.Errorf("Screen must be exported in ANSI and output closed. Found %q", output.String())
+	}
+}
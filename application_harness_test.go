@@ -0,0 +1,114 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+	"github.com/gdamore/tcell"
+)
+
+// Popup without owner (e.g. menu bar) or opened by owner.
+type testPopup struct {
+	owner base.TView
+	keys  int
+
+	base.View
+}
+
+func (p *testPopup) GetOwner() base.TView {
+	return p.owner
+}
+
+// Popup manages F10.
+func (p *testPopup) PreviewKey(ev *tcell.EventKey) bool {
+	if ev.Key() != tcell.KeyF10 {
+		return false
+	}
+
+	p.keys++
+
+	return true
+}
+
+// Add visible and enabled windows. First window is main window and last
+// window is active when application starts.
+func addTestWindows(h *govisiontest.Harness, names ...string) []*base.View {
+	windows := make([]*base.View, 0)
+
+	for _, name := range names {
+		w := base.NewView(name, h.Config.Message, h.App.Canvas())
+		w.SetVisible(true)
+		w.SetEnabled(true)
+
+		h.App.AddWindow(&w)
+
+		windows = append(windows, &w)
+	}
+
+	return windows
+}
+
+// Record activation of windows: "name+" when window is activated, "name-"
+// when window is desactivated.
+func recordActivation(windows []*base.View) *[]string {
+	events := make([]string, 0)
+
+	for _, w := range windows {
+		name := w.Name()
+
+		w.SetOnActivate(func(active bool) {
+			if active {
+				events = append(events, name+"+")
+			} else {
+				events = append(events, name+"-")
+			}
+		})
+	}
+
+	return &events
+}
+
+func checkWindowsOrder(h *govisiontest.Harness, names []string, t *testing.T) {
+	t.Helper()
+
+	wl := h.App.WindowsList()
+
+	if len(wl) != len(names) {
+		t.Errorf("Windows list must have %d windows. Found %d", len(names), len(wl))
+
+		return
+	}
+
+	for i, name := range names {
+		if wl[i].Name() != name {
+			t.Errorf("Window at position %d must be %s. Found %s", i, name, wl[i].Name())
+		}
+	}
+}
+
+// Count messages received by component by type.
+func countMessages(c base.TComponent) map[uint]int {
+	count := make(map[uint]int)
+
+	c.SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		count[msg.Type]++
+
+		return false
+	})
+
+	return count
+}
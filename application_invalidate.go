@@ -0,0 +1,102 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"container/list"
+)
+
+// InvalidRegions return screen areas waiting to be repaint.
+// Becarefull, each call create a new array to return.
+func (a *Application) InvalidRegions() []Rect {
+	r := make([]Rect, len(a.invalidRegions))
+
+	copy(r, a.invalidRegions)
+
+	return r
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Add area to repaint. Overlapping areas are merged.
+func (a *Application) addInvalidRegion(r Rect) {
+	if IsEmpty(r) {
+		return
+	}
+
+	for merged := true; merged; {
+		merged = false
+
+		for i, region := range a.invalidRegions {
+			if !IsEmpty(Intersect(region, r)) {
+				r = Union(region, r)
+
+				a.invalidRegions = append(a.invalidRegions[:i], a.invalidRegions[i+1:]...)
+				merged = true
+
+				break
+			}
+		}
+	}
+
+	a.invalidRegions = append(a.invalidRegions, r)
+}
+
+// Repaint only views in invalid regions. Draw is clipped to region.
+func (a *Application) repaintInvalidRegions() {
+	if len(a.invalidRegions) == 0 {
+		return
+	}
+
+	for _, region := range a.invalidRegions {
+		a.canvas.clipping = true
+		a.canvas.clip = region
+
+		if !a.isCoveredByWindow(a.windowsList.Back(), region) {
+			a.Desktop().HandleMessage(BuildDrawMessage(a.Desktop().Handler()))
+		}
+
+		// Start from bottom window to draw top window at end.
+		for e := a.windowsList.Back(); e != nil; e = e.Prev() {
+			w := e.Value.(TView)
+
+			if !w.GetVisible() || IsEmpty(Intersect(PaintBounds(w), region)) {
+				continue
+			}
+
+			if !a.isCoveredByWindow(e.Prev(), region) {
+				w.HandleMessage(BuildDrawMessage(w.Handler()))
+			}
+		}
+	}
+
+	a.canvas.clipping = false
+	a.invalidRegions = a.invalidRegions[:0]
+}
+
+// Return true if a visible window from `e` to top window fully contains
+// region.
+func (a *Application) isCoveredByWindow(e *list.Element, region Rect) bool {
+	for ; e != nil; e = e.Prev() {
+		w := e.Value.(TView)
+
+		if w.GetVisible() && Contains(w.GetBounds(), region) {
+			return true
+		}
+	}
+
+	return false
+}
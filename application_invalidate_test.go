@@ -0,0 +1,128 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestApplicationInvalidate_merge_regions(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	app.addInvalidRegion(Rect{X: 0, Y: 0, Width: 5, Height: 5})
+	app.addInvalidRegion(Rect{X: 10, Y: 10, Width: 5, Height: 5})
+	app.addInvalidRegion(Rect{X: 0, Y: 0, Width: 0, Height: 5})
+
+	if len(app.InvalidRegions()) != 2 {
+		t.Errorf("Must have 2 regions. Found %+v", app.InvalidRegions())
+	}
+
+	// Join the two regions.
+	app.addInvalidRegion(Rect{X: 4, Y: 4, Width: 7, Height: 7})
+
+	r := Rect{X: 0, Y: 0, Width: 15, Height: 15}
+
+	if regions := app.InvalidRegions(); len(regions) != 1 || regions[0] != r {
+		t.Errorf("Must have only region %+v. Found %+v", r, regions)
+	}
+}
+
+func TestApplicationInvalidate_view_invalidate(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	parent := NewView("parent", appConfig.Message, app.Canvas())
+	parent.SetBounds(Rect{X: 10, Y: 5, Width: 20, Height: 10})
+
+	child := NewView("child", appConfig.Message, parent.ClientCanvas())
+	child.SetBounds(Rect{X: 2, Y: 3, Width: 4, Height: 4})
+	child.SetParent(&parent)
+
+	parent.AddChild(&child)
+
+	child.InvalidateRect(Rect{X: 1, Y: 1, Width: 10, Height: 1})
+
+	msg := <-*appConfig.Message.Channel()
+
+	r := Rect{X: 13, Y: 9, Width: 3, Height: 1}
+
+	if msg.Type != WmInvalidate || msg.Handler != ApplicationHandler() || msg.Value.(Rect) != r {
+		t.Errorf("Invalidate message must be for area %+v. Found %+v", r, msg)
+	}
+}
+
+func TestApplicationInvalidate_repaint_only_region(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	bottom := NewView("bottom", appConfig.Message, app.Canvas())
+	bottom.SetVisible(true)
+	bottom.SetBounds(Rect{X: 0, Y: 0, Width: 10, Height: 10})
+	bottom.SetForegroundColor(tcell.ColorWhite)
+	bottom.SetBackgroundColor(tcell.ColorRed)
+
+	app.AddWindow(&bottom)
+
+	top := NewView("top", appConfig.Message, app.Canvas())
+	top.SetVisible(true)
+	top.SetBounds(Rect{X: 5, Y: 0, Width: 10, Height: 10})
+	top.SetForegroundColor(tcell.ColorWhite)
+	top.SetBackgroundColor(tcell.ColorBlue)
+
+	app.AddWindow(&top)
+
+	if e := app.Init(); e != nil {
+		t.Error("Cannot initialize screen")
+	}
+
+	defer appConfig.Screen.Fini()
+
+	appConfig.Screen.Clear()
+
+	app.addInvalidRegion(Rect{X: 3, Y: 0, Width: 4, Height: 1})
+	app.repaintInvalidRegions()
+
+	appConfig.Screen.Show()
+
+	red := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorRed)
+	blue := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlue)
+
+	for x, st := range map[int]tcell.Style{3: red, 4: red, 5: blue, 6: blue} {
+		if e := checkCell(appConfig.Screen, x, 0, ' ', st, t); e != nil {
+			t.Error(e)
+		}
+	}
+
+	// Out of region, nothing draw.
+	for _, x := range []int{2, 7} {
+		if e := checkCell(appConfig.Screen, x, 0, ' ', tcell.StyleDefault, t); e != nil {
+			t.Error(e)
+		}
+	}
+
+	if e := checkCell(appConfig.Screen, 3, 1, ' ', tcell.StyleDefault, t); e != nil {
+		t.Error(e)
+	}
+
+	if len(app.InvalidRegions()) != 0 {
+		t.Error("Invalid regions must be empty after repaint")
+	}
+}
@@ -0,0 +1,149 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"container/list"
+	"encoding/json"
+	"io"
+)
+
+// WindowLayout is saved state of a top-level window.
+type WindowLayout struct {
+	// Name of window. Use to find window when restore layout.
+	Name string `json:"name"`
+	// Position and size.
+	Bounds Rect `json:"bounds"`
+	// Visible window.
+	Visible bool `json:"visible"`
+	// Enabled window.
+	Enabled bool `json:"enabled"`
+	// Window stay above normal windows.
+	StayOnTop bool `json:"stayOnTop"`
+	// Window has focus.
+	Active bool `json:"active"`
+}
+
+// DesktopLayout is saved state of all top-level windows.
+type DesktopLayout struct {
+	// Windows in stacking order. The first item is the top window.
+	Windows []WindowLayout `json:"windows"`
+}
+
+// SaveLayout write top-level windows layout in JSON. Popups are not saved.
+func (a *Application) SaveLayout(w io.Writer) error {
+	layout := DesktopLayout{
+		Windows: make([]WindowLayout, 0),
+	}
+
+	active := a.ActiveWindow()
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		v := e.Value.(TView)
+
+		// Popups (menus, drop-down lists) are not part of layout.
+		if layerOf(v) == layerPopup {
+			continue
+		}
+
+		layout.Windows = append(layout.Windows, WindowLayout{
+			Name:      v.Name(),
+			Bounds:    v.GetBounds(),
+			Visible:   v.GetVisible(),
+			Enabled:   v.GetEnabled(),
+			StayOnTop: v.GetStayOnTop(),
+			Active:    active != nil && active.Handler() == v.Handler(),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(layout)
+}
+
+// LoadLayout read top-level windows layout in JSON and restore it. Windows
+// are found by name, unknown names and popups are ignored. Windows are clamped to screen
+// size.
+// Must be call before Run() or in a message handler.
+func (a *Application) LoadLayout(r io.Reader) error {
+	var layout DesktopLayout
+
+	if e := json.NewDecoder(r).Decode(&layout); e != nil {
+		return e
+	}
+
+	screenWidth, screenHeight := a.canvas.screen.Size()
+
+	var activeElement *list.Element
+
+	// Start from bottom window to finish with top window.
+	for i := len(layout.Windows) - 1; i >= 0; i-- {
+		wl := layout.Windows[i]
+
+		e := a.findWindowsByName(wl.Name)
+
+		if e == nil || layerOf(e.Value.(TView)) == layerPopup {
+			continue
+		}
+
+		v := e.Value.(TView)
+
+		// Window applies its constraints (e.g. minimum size) and repaints.
+		v.HandleMessage(BuildChangeBoundsMessage(v.Handler(), clampBounds(wl.Bounds, screenWidth, screenHeight)))
+		v.SetVisible(wl.Visible)
+		v.SetEnabled(wl.Enabled)
+		v.SetStayOnTop(wl.StayOnTop)
+
+		a.windowsList.MoveToFront(e)
+
+		if wl.Active {
+			activeElement = e
+		}
+	}
+
+	a.sortWindowsByLayer()
+
+	if activeElement != nil && canBeActivated(activeElement.Value.(TView)) {
+		a.activateWindow(a.ActiveWindow(), activeElement)
+	}
+
+	a.message.Send(BuildDrawMessage(BroadcastHandler()))
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func (a *Application) findWindowsByName(name string) *list.Element {
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		if e.Value.(TView).Name() == name {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// Keep bounds in screen.
+func clampBounds(r Rect, screenWidth int, screenHeight int) Rect {
+	r.Width = MaxInt(MinInt(r.Width, screenWidth), 0)
+	r.Height = MaxInt(MinInt(r.Height, screenHeight), 0)
+	r.X = MaxInt(MinInt(r.X, screenWidth-r.Width), 0)
+	r.Y = MaxInt(MinInt(r.Y, screenHeight-r.Height), 0)
+
+	return r
+}
@@ -0,0 +1,143 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+func createLayoutTestHarness(t *testing.T) (*govisiontest.Harness, []*base.View) {
+	h := govisiontest.NewHarness(t, 80, 25)
+
+	windows := addTestWindows(h, "window1", "window2", "window3")
+
+	for i, w := range windows {
+		w.SetBounds(base.Rect{
+			X:      i,
+			Y:      i,
+			Width:  10,
+			Height: 5,
+		})
+	}
+
+	return h, windows
+}
+
+func TestApplicationLayout_save_and_load(t *testing.T) {
+	h, windows := createLayoutTestHarness(t)
+	defer h.Close()
+
+	windows[1].SetVisible(false)
+	h.App.BringToFront(windows[0])
+
+	h.App.AddWindow(&testPopup{View: base.NewView("popup", h.Config.Message, h.App.Canvas())})
+
+	h.Start()
+
+	var buffer bytes.Buffer
+
+	if e := h.App.SaveLayout(&buffer); e != nil {
+		t.Errorf("Cannot save layout: %+v", e)
+	}
+
+	if strings.Contains(buffer.String(), "popup") {
+		t.Error("Popup must not be saved")
+	}
+
+	h2, windows2 := createLayoutTestHarness(t)
+	defer h2.Close()
+
+	h2.Start()
+
+	changed := 0
+
+	windows2[0].SetOnChangeBounds(func(base.Rect) {
+		changed++
+	})
+
+	if e := h2.App.LoadLayout(&buffer); e != nil {
+		t.Errorf("Cannot load layout: %+v", e)
+	}
+
+	h2.WaitIdle()
+
+	checkWindowsOrder(h2, []string{"window1", "window3", "window2"}, t)
+
+	if changed != 1 {
+		t.Error("Window must be notified of new bounds")
+	}
+
+	if windows2[1].GetVisible() {
+		t.Error("Window2 must be hidden")
+	}
+
+	for i, w := range windows2 {
+		if w.GetBounds() != windows[i].GetBounds() {
+			t.Errorf("Wrong bounds for %s: %+v", w.Name(), w.GetBounds())
+		}
+	}
+}
+
+func TestApplicationLayout_load_unknown_window_and_clamp(t *testing.T) {
+	h, windows := createLayoutTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+
+	layout := `{
+  "windows": [
+    {"name": "unknown", "bounds": {"X": 1, "Y": 1, "Width": 1, "Height": 1}, "visible": true, "enabled": true},
+    {"name": "window2", "bounds": {"X": 75, "Y": -3, "Width": 200, "Height": 10}, "visible": true, "enabled": true, "active": true}
+  ]
+}`
+
+	if e := h.App.LoadLayout(strings.NewReader(layout)); e != nil {
+		t.Errorf("Cannot load layout: %+v", e)
+	}
+
+	h.WaitIdle()
+
+	r := base.Rect{
+		X:      0,
+		Y:      0,
+		Width:  80,
+		Height: 10,
+	}
+
+	if windows[1].GetBounds() != r {
+		t.Errorf("Window2 must be clamped to %+v. Found %+v", r, windows[1].GetBounds())
+	}
+
+	if h.App.ActiveWindow() != windows[1] {
+		t.Error("Window2 must be active")
+	}
+
+	checkWindowsOrder(h, []string{"window2", "window3", "window1"}, t)
+}
+
+func TestApplicationLayout_load_bad_json(t *testing.T) {
+	h, _ := createLayoutTestHarness(t)
+	defer h.Close()
+
+	h.Start()
+
+	if e := h.App.LoadLayout(strings.NewReader("{")); e == nil {
+		t.Error("Error must be return")
+	}
+}
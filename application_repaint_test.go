@@ -0,0 +1,66 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+// Create two overlapped windows and return the top one.
+func createRepaintTestHarness(t *testing.T) (*govisiontest.Harness, *base.View) {
+	h := govisiontest.NewHarness(t, 14, 6)
+
+	windows := addTestWindows(h, "window1", "window2")
+	windows[0].SetBounds(base.Rect{X: 1, Y: 1, Width: 6, Height: 3})
+	windows[1].SetBounds(base.Rect{X: 4, Y: 2, Width: 8, Height: 3})
+
+	h.Start()
+
+	return h, windows[1]
+}
+
+func checkScreen(h *govisiontest.Harness, want string, t *testing.T) {
+	t.Helper()
+
+	if got := h.ScreenText(); got != want {
+		t.Errorf("Screen must be:\n%sFound:\n%s", want, got)
+	}
+}
+
+func TestApplicationRepaint_destroy_window(t *testing.T) {
+	h, w2 := createRepaintTestHarness(t)
+	defer h.Close()
+
+	h.Send(base.Message{
+		Handler: base.ApplicationHandler(),
+		Type:    base.WmDestroy,
+		Value:   w2,
+	})
+
+	checkScreen(h, "░░░░░░░░░░░░░░\n░      ░░░░░░░\n░      ░░░░░░░\n░      ░░░░░░░\n░░░░░░░░░░░░░░\n░░░░░░░░░░░░░░\n", t)
+}
+
+func TestApplicationRepaint_hide_window(t *testing.T) {
+	h, w2 := createRepaintTestHarness(t)
+	defer h.Close()
+
+	w2.SetVisible(false)
+	h.WaitIdle()
+
+	checkScreen(h, "░░░░░░░░░░░░░░\n░      ░░░░░░░\n░      ░░░░░░░\n░      ░░░░░░░\n░░░░░░░░░░░░░░\n░░░░░░░░░░░░░░\n", t)
+}
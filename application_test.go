@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell"
 )
@@ -628,3 +629,55 @@ func TestApplication_Mouse_enter_mouse_leave_with_multi_window(t *testing.T) {
 		}
 	}
 }
+
+func TestApplication_Mouse_double_click(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	now := time.Now()
+
+	app.Clock = func() time.Time {
+		return now
+	}
+
+	window := NewView("window", appConfig.Message, app.Canvas())
+	window.SetEnabled(true)
+	window.SetVisible(true)
+	window.SetBounds(Rect{X: 0, Y: 0, Width: 20, Height: 10})
+
+	app.AddWindow(&window)
+	app.Init()
+	app.Start()
+	app.Step()
+
+	click := func(x, y int, delay time.Duration) int {
+		now = now.Add(delay)
+
+		app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone)))
+		app.manageMessage(BuildMouseMessage(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone)))
+
+		count := 0
+
+		for len(*appConfig.Message.Channel()) > 0 {
+			if msg := <-*appConfig.Message.Channel(); msg.Type == WmLButtonDblClick && msg.Handler == window.Handler() {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	results := []int{
+		click(3, 3, 0),
+		click(3, 3, 100*time.Millisecond),
+		// Third click is a new first click.
+		click(3, 3, 100*time.Millisecond),
+		click(4, 3, 100*time.Millisecond),
+		click(4, 3, time.Second),
+	}
+
+	if fmt.Sprint(results) != "[0 1 0 0 0]" {
+		t.Errorf("Only second click must be a double click. Found %v", results)
+	}
+}
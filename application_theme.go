@@ -0,0 +1,193 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+// Theme return theme of application.
+func (a *Application) Theme() *Theme {
+	if a.theme == nil {
+		return defaultTheme
+	}
+
+	return a.theme
+}
+
+// SetTheme change theme of application. All views are notified with
+// WmThemeChanged message and screen is repaint.
+func (a *Application) SetTheme(t *Theme) {
+	a.theme = t
+
+	a.notifyTheme()
+}
+
+// Stylesheet return stylesheet of application or nil.
+func (a *Application) Stylesheet() *Stylesheet {
+	return a.stylesheet
+}
+
+// SetStylesheet change stylesheet of application. Stylesheet is added to
+// theme, so views are notified with WmThemeChanged message.
+func (a *Application) SetStylesheet(s *Stylesheet) {
+	a.stylesheet = s
+
+	a.notifyTheme()
+}
+
+// LoadStylesheet read stylesheet file and use it.
+func (a *Application) LoadStylesheet(filename string) error {
+	s, e := readStylesheetFile(filename)
+
+	if e != nil {
+		return e
+	}
+
+	a.SetStylesheet(s)
+
+	return nil
+}
+
+// WatchStylesheet check stylesheet file at each interval and reload it when
+// file change. On error (e.g. syntax error), previous stylesheet is kept and
+// onError is called (can be nil) from watcher goroutine.
+// Return function to stop watch.
+func (a *Application) WatchStylesheet(filename string, interval time.Duration, onError func(error)) func() {
+	stop := make(chan bool)
+	last := stylesheetFileVersion(filename)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				current := stylesheetFileVersion(filename)
+
+				if current == last {
+					continue
+				}
+
+				last = current
+
+				if s, e := readStylesheetFile(filename); e != nil {
+					if onError != nil {
+						onError(e)
+					}
+				} else {
+					a.message.Send(Message{
+						Handler: ApplicationHandler(),
+						Type:    WmStylesheetChanged,
+						Value:   s,
+					})
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(stop)
+		})
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Theme with stylesheet, sent to views.
+func (a *Application) currentTheme() *Theme {
+	if a.appliedTheme == nil {
+		return a.Theme()
+	}
+
+	return a.appliedTheme
+}
+
+// Send theme with stylesheet to views and repaint.
+func (a *Application) notifyTheme() {
+	t := a.Theme()
+
+	if a.stylesheet != nil {
+		t = t.Copy(t.Name)
+		t.Stylesheet = a.stylesheet
+	}
+
+	a.appliedTheme = t
+
+	a.message.Send(BuildThemeChangedMessage(t))
+	a.message.Send(BuildDrawMessage(BroadcastHandler()))
+}
+
+// Set theme of application to new top-level window.
+func (a *Application) applyTheme(w TView) {
+	if a.appliedTheme != nil {
+		w.HandleMessage(BuildThemeChangedMessage(a.appliedTheme))
+	}
+}
+
+// Return default theme with desktop style of screen style or nil (default
+// theme) if screen style is not set or is desktop style of default theme.
+func screenTheme(brush tcell.Style, screenStyle ApplicationStyle) *Theme {
+	if screenStyle == (ApplicationStyle{}) || brush == defaultTheme.Style(RoleDesktop) {
+		return nil
+	}
+
+	t := defaultTheme.Copy(defaultTheme.Name)
+	t.SetStyle(RoleDesktop, brush)
+
+	return t
+}
+
+// Version of file to know if file change.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func stylesheetFileVersion(filename string) fileVersion {
+	info, e := os.Stat(filename)
+
+	if e != nil {
+		return fileVersion{}
+	}
+
+	return fileVersion{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+}
+
+func readStylesheetFile(filename string) (*Stylesheet, error) {
+	f, e := os.Open(filename)
+
+	if e != nil {
+		return nil, e
+	}
+
+	defer f.Close()
+
+	return ParseStylesheet(f)
+}
@@ -0,0 +1,155 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"container/list"
+)
+
+// BringToFront move top-level window above all windows of same layer.
+// Stay-on-top windows always stay above normal windows, popups above all.
+func (a *Application) BringToFront(w TView) {
+	e, _ := a.findWindowsByHandle(w.Handler())
+
+	if e == nil {
+		return
+	}
+
+	a.sortWindowsByLayer()
+	a.moveToFrontOfLayer(e)
+
+	a.message.Send(BuildDrawMessage(BroadcastHandler()))
+}
+
+// SendToBack move top-level window under all windows of same layer.
+func (a *Application) SendToBack(w TView) {
+	e, _ := a.findWindowsByHandle(w.Handler())
+
+	if e == nil {
+		return
+	}
+
+	a.sortWindowsByLayer()
+
+	if last := a.lastOfLayer(layerOf(w)); last != e {
+		a.windowsList.MoveAfter(e, last)
+	}
+
+	a.message.Send(BuildDrawMessage(BroadcastHandler()))
+}
+
+// SetTopLevelZorder move top-level window at `index` position in his layer.
+// 0 is the top. Index is clamped to layer size.
+func (a *Application) SetTopLevelZorder(w TView, index int) {
+	e, _ := a.findWindowsByHandle(w.Handler())
+
+	if e == nil {
+		return
+	}
+
+	a.sortWindowsByLayer()
+	a.moveToFrontOfLayer(e)
+
+	for ; index > 0; index-- {
+		next := e.Next()
+
+		if next == nil || layerOf(next.Value.(TView)) != layerOf(w) {
+			break
+		}
+
+		a.windowsList.MoveAfter(e, next)
+	}
+
+	a.message.Send(BuildDrawMessage(BroadcastHandler()))
+}
+
+// TopLevelZorder return position of top-level window in his layer (0 is the
+// top, like SetTopLevelZorder) or -1 if not found.
+func (a *Application) TopLevelZorder(w TView) int {
+	a.sortWindowsByLayer()
+
+	index := 0
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		v := e.Value.(TView)
+
+		if v.Handler() == w.Handler() {
+			return index
+		}
+
+		if layerOf(v) == layerOf(w) {
+			index++
+		}
+	}
+
+	return -1
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Move windows of upper layers before windows of lower layers. Order in each
+// layer is kept.
+func (a *Application) sortWindowsByLayer() {
+	for layer := layerStayOnTop; layer <= layerPopup; layer++ {
+		var lastOfLayer *list.Element
+
+		for e := a.windowsList.Front(); e != nil; {
+			next := e.Next()
+
+			if layerOf(e.Value.(TView)) == layer {
+				if lastOfLayer == nil {
+					a.windowsList.MoveToFront(e)
+				} else if lastOfLayer.Next() != e {
+					a.windowsList.MoveAfter(e, lastOfLayer)
+				}
+
+				lastOfLayer = e
+			}
+
+			e = next
+		}
+	}
+}
+
+// Move window at front of his layer. Windows list must be sorted by layer.
+func (a *Application) moveToFrontOfLayer(e *list.Element) {
+	var above *list.Element
+
+	// Last window of upper layers.
+	for layer := layerOf(e.Value.(TView)) + 1; layer <= layerPopup && above == nil; layer++ {
+		above = a.lastOfLayer(layer)
+	}
+
+	if above == nil {
+		a.windowsList.MoveToFront(e)
+	} else if above.Next() != e {
+		a.windowsList.MoveAfter(e, above)
+	}
+}
+
+// Return last window of layer or nil if layer is empty.
+// Windows list must be sorted by layer.
+func (a *Application) lastOfLayer(layer int) *list.Element {
+	var last *list.Element
+
+	for e := a.windowsList.Front(); e != nil; e = e.Next() {
+		if layerOf(e.Value.(TView)) == layer {
+			last = e
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,128 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+// Windows list is: palette, window3, window2, window1.
+func createZorderTestHarness(t *testing.T) (*govisiontest.Harness, []*base.View) {
+	h := govisiontest.NewHarness(t, 80, 25)
+
+	windows := addTestWindows(h, "window1", "palette")
+	windows[1].SetStayOnTop(true)
+
+	// Palette stay on top when windows are added.
+	windows = append(windows, addTestWindows(h, "window2", "window3")...)
+
+	h.Start()
+
+	return h, windows
+}
+
+func TestApplicationZorder_stay_on_top_after_AddWindow(t *testing.T) {
+	h, _ := createZorderTestHarness(t)
+	defer h.Close()
+
+	checkWindowsOrder(h, []string{"palette", "window3", "window2", "window1"}, t)
+}
+
+func TestApplicationZorder_BringToFront(t *testing.T) {
+	h, windows := createZorderTestHarness(t)
+	defer h.Close()
+
+	h.App.BringToFront(windows[0])
+
+	checkWindowsOrder(h, []string{"palette", "window1", "window3", "window2"}, t)
+}
+
+func TestApplicationZorder_SendToBack(t *testing.T) {
+	h, windows := createZorderTestHarness(t)
+	defer h.Close()
+
+	h.App.SendToBack(windows[3])
+
+	checkWindowsOrder(h, []string{"palette", "window2", "window1", "window3"}, t)
+
+	// Stay on top window stay above normal windows.
+	h.App.SendToBack(windows[1])
+
+	checkWindowsOrder(h, []string{"palette", "window2", "window1", "window3"}, t)
+}
+
+func TestApplicationZorder_SetTopLevelZorder(t *testing.T) {
+	h, windows := createZorderTestHarness(t)
+	defer h.Close()
+
+	h.App.SetTopLevelZorder(windows[3], 1)
+
+	checkWindowsOrder(h, []string{"palette", "window2", "window3", "window1"}, t)
+
+	h.App.SetTopLevelZorder(windows[2], 10)
+
+	checkWindowsOrder(h, []string{"palette", "window3", "window1", "window2"}, t)
+
+	if z := h.App.TopLevelZorder(windows[2]); z != 2 {
+		t.Errorf("Zorder of window2 must be 2. Found %d", z)
+	}
+}
+
+func TestApplicationZorder_TopLevelZorder_round_trip(t *testing.T) {
+	h, windows := createZorderTestHarness(t)
+	defer h.Close()
+
+	for _, w := range windows {
+		z := h.App.TopLevelZorder(w)
+
+		h.App.SetTopLevelZorder(w, z)
+
+		checkWindowsOrder(h, []string{"palette", "window3", "window2", "window1"}, t)
+
+		if h.App.TopLevelZorder(w) != z {
+			t.Errorf("Zorder of %s must be %d. Found %d", w.Name(), z, h.App.TopLevelZorder(w))
+		}
+	}
+
+	if z := h.App.TopLevelZorder(windows[1]); z != 0 {
+		t.Errorf("Zorder of palette must be 0 in its layer. Found %d", z)
+	}
+
+	// Reverse order.
+	for i, w := range []*base.View{windows[0], windows[2], windows[3]} {
+		h.App.SetTopLevelZorder(w, i)
+	}
+
+	checkWindowsOrder(h, []string{"palette", "window1", "window2", "window3"}, t)
+
+	for i, w := range []*base.View{windows[0], windows[2], windows[3]} {
+		if z := h.App.TopLevelZorder(w); z != i {
+			t.Errorf("Zorder of %s must be %d. Found %d", w.Name(), i, z)
+		}
+	}
+}
+
+func TestApplicationZorder_change_stay_on_top(t *testing.T) {
+	h, windows := createZorderTestHarness(t)
+	defer h.Close()
+
+	windows[0].SetStayOnTop(true)
+
+	h.App.BringToFront(windows[2])
+
+	checkWindowsOrder(h, []string{"palette", "window1", "window2", "window3"}, t)
+}
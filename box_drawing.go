@@ -0,0 +1,283 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// LineStyle is style of line to draw box.
+type LineStyle int
+
+const (
+	// LineStyleSingle line like ┌─┐.
+	LineStyleSingle LineStyle = 0
+	// LineStyleDouble line like ╔═╗.
+	LineStyleDouble LineStyle = 1
+	// LineStyleHeavy line like ┏━┓.
+	LineStyleHeavy LineStyle = 2
+	// LineStyleRounded line like ╭─╮.
+	LineStyleRounded LineStyle = 3
+	// LineStyleASCII line like +-+.
+	LineStyleASCII LineStyle = 4
+	// LineStyleBlank line draw with space.
+	LineStyleBlank LineStyle = 5
+)
+
+// Weight of line arm in a box drawing character.
+type lineWeight uint8
+
+const (
+	weightNone   lineWeight = 0
+	weightLight  lineWeight = 1
+	weightHeavy  lineWeight = 2
+	weightDouble lineWeight = 3
+)
+
+// Index of arms.
+const (
+	armUp    = 0
+	armRight = 1
+	armDown  = 2
+	armLeft  = 3
+)
+
+// Arms of box drawing character (up, right, down, left).
+type lineArms [4]lineWeight
+
+// All box drawing characters that can be join.
+var boxGlyphs = map[rune]lineArms{
+	'─': {0, 1, 0, 1}, '│': {1, 0, 1, 0}, '┌': {0, 1, 1, 0}, '┐': {0, 0, 1, 1},
+	'└': {1, 1, 0, 0}, '┘': {1, 0, 0, 1}, '├': {1, 1, 1, 0}, '┤': {1, 0, 1, 1},
+	'┬': {0, 1, 1, 1}, '┴': {1, 1, 0, 1}, '┼': {1, 1, 1, 1},
+
+	'━': {0, 2, 0, 2}, '┃': {2, 0, 2, 0}, '┏': {0, 2, 2, 0}, '┓': {0, 0, 2, 2},
+	'┗': {2, 2, 0, 0}, '┛': {2, 0, 0, 2}, '┣': {2, 2, 2, 0}, '┫': {2, 0, 2, 2},
+	'┳': {0, 2, 2, 2}, '┻': {2, 2, 0, 2}, '╋': {2, 2, 2, 2},
+
+	'═': {0, 3, 0, 3}, '║': {3, 0, 3, 0}, '╔': {0, 3, 3, 0}, '╗': {0, 0, 3, 3},
+	'╚': {3, 3, 0, 0}, '╝': {3, 0, 0, 3}, '╠': {3, 3, 3, 0}, '╣': {3, 0, 3, 3},
+	'╦': {0, 3, 3, 3}, '╩': {3, 3, 0, 3}, '╬': {3, 3, 3, 3},
+
+	'╒': {0, 3, 1, 0}, '╓': {0, 1, 3, 0}, '╕': {0, 0, 1, 3}, '╖': {0, 0, 3, 1},
+	'╘': {1, 3, 0, 0}, '╙': {3, 1, 0, 0}, '╛': {1, 0, 0, 3}, '╜': {3, 0, 0, 1},
+	'╞': {1, 3, 1, 0}, '╟': {3, 1, 3, 0}, '╡': {1, 0, 1, 3}, '╢': {3, 0, 3, 1},
+	'╤': {0, 3, 1, 3}, '╥': {0, 1, 3, 1}, '╧': {1, 3, 0, 3}, '╨': {3, 1, 0, 1},
+	'╪': {1, 3, 1, 3}, '╫': {3, 1, 3, 1},
+}
+
+// Rounded corners, only use for corner without junction.
+var roundedCorners = map[lineArms]rune{
+	{0, 1, 1, 0}: '╭', {0, 0, 1, 1}: '╮', {1, 1, 0, 0}: '╰', {1, 0, 0, 1}: '╯',
+}
+
+// ASCII characters that can be join.
+var asciiGlyphs = map[rune]lineArms{
+	'-': {0, 1, 0, 1}, '|': {1, 0, 1, 0}, '+': {1, 1, 1, 1},
+}
+
+var glyphsByArms map[lineArms]rune
+
+func init() {
+	glyphsByArms = make(map[lineArms]rune, len(boxGlyphs))
+
+	for glyph, arms := range boxGlyphs {
+		glyphsByArms[arms] = glyph
+	}
+
+	for arms, glyph := range roundedCorners {
+		boxGlyphs[glyph] = arms
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func (s LineStyle) weight() lineWeight {
+	switch s {
+	case LineStyleDouble:
+		return weightDouble
+	case LineStyleHeavy:
+		return weightHeavy
+	default:
+		return weightLight
+	}
+}
+
+// Draw horizontal line with `length` cells.
+func drawHLine(c TCanvas, x int, y int, length int, style LineStyle, brush tcell.Style) {
+	w := style.weight()
+
+	for i := 0; i < length; i++ {
+		arms := lineArms{}
+
+		if i > 0 || length == 1 {
+			arms[armLeft] = w
+		}
+
+		if i < length-1 || length == 1 {
+			arms[armRight] = w
+		}
+
+		drawBoxCell(c, x+i, y, arms, style, brush)
+	}
+}
+
+// Draw vertical line with `length` cells.
+func drawVLine(c TCanvas, x int, y int, length int, style LineStyle, brush tcell.Style) {
+	w := style.weight()
+
+	for i := 0; i < length; i++ {
+		arms := lineArms{}
+
+		if i > 0 || length == 1 {
+			arms[armUp] = w
+		}
+
+		if i < length-1 || length == 1 {
+			arms[armDown] = w
+		}
+
+		drawBoxCell(c, x, y+i, arms, style, brush)
+	}
+}
+
+// Draw rectangle border.
+func drawRect(c TCanvas, r Rect, style LineStyle, brush tcell.Style) {
+	if IsEmpty(r) {
+		return
+	}
+
+	if r.Height == 1 {
+		drawHLine(c, r.X, r.Y, r.Width, style, brush)
+
+		return
+	}
+
+	if r.Width == 1 {
+		drawVLine(c, r.X, r.Y, r.Height, style, brush)
+
+		return
+	}
+
+	w := style.weight()
+	right := r.X + r.Width - 1
+	bottom := r.Y + r.Height - 1
+
+	drawBoxCell(c, r.X, r.Y, lineArms{armRight: w, armDown: w}, style, brush)
+	drawBoxCell(c, right, r.Y, lineArms{armDown: w, armLeft: w}, style, brush)
+	drawBoxCell(c, r.X, bottom, lineArms{armUp: w, armRight: w}, style, brush)
+	drawBoxCell(c, right, bottom, lineArms{armUp: w, armLeft: w}, style, brush)
+
+	for x := r.X + 1; x < right; x++ {
+		drawBoxCell(c, x, r.Y, lineArms{armRight: w, armLeft: w}, style, brush)
+		drawBoxCell(c, x, bottom, lineArms{armRight: w, armLeft: w}, style, brush)
+	}
+
+	for y := r.Y + 1; y < bottom; y++ {
+		drawBoxCell(c, r.X, y, lineArms{armUp: w, armDown: w}, style, brush)
+		drawBoxCell(c, right, y, lineArms{armUp: w, armDown: w}, style, brush)
+	}
+}
+
+// Draw rectangle border and fill inside.
+func drawFrame(c TCanvas, r Rect, style LineStyle, brush tcell.Style) {
+	for y := r.Y + 1; y < r.Y+r.Height-1; y++ {
+		for x := r.X + 1; x < r.X+r.Width-1; x++ {
+			c.PrintCellWithBrush(x, y, ' ', nil, brush)
+		}
+	}
+
+	drawRect(c, r, style, brush)
+}
+
+// Draw a line cell. If a line is already in cell, choose junction character.
+func drawBoxCell(c TCanvas, x int, y int, arms lineArms, style LineStyle, brush tcell.Style) {
+	var glyph rune
+
+	switch style {
+	case LineStyleBlank:
+		glyph = ' '
+	case LineStyleASCII:
+		glyph = asciiGlyph(joinArms(arms, asciiGlyphs, c.GetCell(x, y).Char))
+	default:
+		merged := joinArms(arms, boxGlyphs, c.GetCell(x, y).Char)
+
+		if r, ok := roundedCorners[merged]; ok && style == LineStyleRounded {
+			glyph = r
+		} else {
+			glyph = boxGlyph(merged, arms)
+		}
+	}
+
+	c.PrintCellWithBrush(x, y, glyph, nil, brush)
+}
+
+// Add arms of existing character.
+func joinArms(arms lineArms, glyphs map[rune]lineArms, existing rune) lineArms {
+	if old, ok := glyphs[existing]; ok {
+		for i := range arms {
+			if arms[i] == weightNone {
+				arms[i] = old[i]
+			}
+		}
+	}
+
+	return arms
+}
+
+// Find character for arms. If not exists (e.g. end of line or unknown mix of
+// line weight), use straight line.
+func boxGlyph(merged lineArms, arms lineArms) rune {
+	for _, a := range []lineArms{merged, straighten(merged), arms, straighten(arms)} {
+		if g, ok := glyphsByArms[a]; ok {
+			return g
+		}
+	}
+
+	return ' '
+}
+
+func asciiGlyph(arms lineArms) rune {
+	horizontal := arms[armLeft] != weightNone || arms[armRight] != weightNone
+	vertical := arms[armUp] != weightNone || arms[armDown] != weightNone
+
+	switch {
+	case horizontal && vertical:
+		return '+'
+	case vertical:
+		return '|'
+	default:
+		return '-'
+	}
+}
+
+// Complete line end to have a full line.
+func straighten(arms lineArms) lineArms {
+	h := lineWeight(MaxInt(int(arms[armLeft]), int(arms[armRight])))
+	v := lineWeight(MaxInt(int(arms[armUp]), int(arms[armDown])))
+
+	if h != weightNone {
+		arms[armLeft] = h
+		arms[armRight] = h
+	}
+
+	if v != weightNone {
+		arms[armUp] = v
+		arms[armDown] = v
+	}
+
+	return arms
+}
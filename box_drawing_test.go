@@ -0,0 +1,140 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func checkMemoryLines(m *MemoryCanvas, lines []string, t *testing.T) {
+	for y, line := range lines {
+		x := 0
+
+		for _, c := range line {
+			if cell := m.GetCell(x, y); cell.Char != c {
+				t.Errorf("Incorrect cell content at (x: %d, y: %d). Want '%c': Found '%c'", x, y, c, cell.Char)
+			}
+
+			x++
+		}
+	}
+}
+
+func TestBoxDrawing_rect(t *testing.T) {
+	tests := map[LineStyle][]string{
+		LineStyleSingle:  {"┌──┐", "│  │", "└──┘"},
+		LineStyleDouble:  {"╔══╗", "║  ║", "╚══╝"},
+		LineStyleHeavy:   {"┏━━┓", "┃  ┃", "┗━━┛"},
+		LineStyleRounded: {"╭──╮", "│  │", "╰──╯"},
+		LineStyleASCII:   {"+--+", "|  |", "+--+"},
+	}
+
+	for style, lines := range tests {
+		m := NewMemoryCanvas(4, 3)
+		m.DrawRect(Rect{X: 0, Y: 0, Width: 4, Height: 3}, style)
+
+		checkMemoryLines(m, lines, t)
+	}
+}
+
+func TestBoxDrawing_junction(t *testing.T) {
+	m := NewMemoryCanvas(5, 5)
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 5, Height: 5}, LineStyleSingle)
+	m.DrawHLine(0, 2, 5, LineStyleSingle)
+	m.DrawVLine(2, 0, 5, LineStyleSingle)
+
+	checkMemoryLines(m, []string{
+		"┌─┬─┐",
+		"│ │ │",
+		"├─┼─┤",
+		"│ │ │",
+		"└─┴─┘",
+	}, t)
+}
+
+func TestBoxDrawing_junction_double_single(t *testing.T) {
+	m := NewMemoryCanvas(5, 3)
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 5, Height: 3}, LineStyleDouble)
+	m.DrawVLine(2, 0, 3, LineStyleSingle)
+
+	checkMemoryLines(m, []string{
+		"╔═╤═╗",
+		"║ │ ║",
+		"╚═╧═╝",
+	}, t)
+
+	// Line in middle of rounded box, corner stay rounded.
+	m = NewMemoryCanvas(3, 3)
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 3, Height: 3}, LineStyleRounded)
+	m.DrawHLine(0, 1, 3, LineStyleRounded)
+
+	checkMemoryLines(m, []string{
+		"╭─╮",
+		"├─┤",
+		"╰─╯",
+	}, t)
+
+	m = NewMemoryCanvas(3, 3)
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 3, Height: 3}, LineStyleASCII)
+	m.DrawHLine(0, 1, 3, LineStyleASCII)
+
+	checkMemoryLines(m, []string{
+		"+-+",
+		"+-+",
+		"+-+",
+	}, t)
+}
+
+func TestBoxDrawing_frame(t *testing.T) {
+	st := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorBlue)
+
+	m := NewMemoryCanvas(5, 4)
+	m.PrintString(0, 1, "XXXXX")
+	m.SetBrush(st)
+	m.DrawFrame(Rect{X: 0, Y: 0, Width: 5, Height: 4}, LineStyleSingle)
+
+	checkMemoryLines(m, []string{
+		"┌───┐",
+		"│   │",
+		"│   │",
+		"└───┘",
+	}, t)
+
+	checkMemoryCell(m, 2, 1, ' ', st, t)
+	checkMemoryCell(m, 0, 0, '┌', st, t)
+
+	// Blank style erase border.
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 5, Height: 4}, LineStyleBlank)
+
+	checkMemoryLines(m, []string{
+		"     ",
+		"     ",
+	}, t)
+}
+
+func TestBoxDrawing_degenerated_rect(t *testing.T) {
+	m := NewMemoryCanvas(4, 3)
+	m.DrawRect(Rect{X: 0, Y: 0, Width: 4, Height: 1}, LineStyleSingle)
+	m.DrawRect(Rect{X: 0, Y: 1, Width: 1, Height: 2}, LineStyleDouble)
+	m.DrawRect(Rect{X: 2, Y: 1, Width: 0, Height: 2}, LineStyleSingle)
+
+	checkMemoryLines(m, []string{
+		"────",
+		"║   ",
+		"║   ",
+	}, t)
+}
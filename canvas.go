@@ -47,6 +47,21 @@ func (c *Canvas) UpdateBounds(r Rect) {
 	}
 }
 
+// SetOrigin translate canvas: point (x, y) is draw at top-left corner of
+// canvas. Draw area keep its size, so content is clipped.
+func (c *Canvas) SetOrigin(x, y int) {
+	c.offset.X += c.draw.X - x
+	c.offset.Y += c.draw.Y - y
+
+	c.draw.X = x
+	c.draw.Y = y
+}
+
+// GetOrigin return point draw at top-left corner of canvas.
+func (c *Canvas) GetOrigin() (int, int) {
+	return c.draw.X, c.draw.Y
+}
+
 // CreateCanvasFrom create a sub-canvas for `r` parameter.
 func (c *Canvas) CreateCanvasFrom(r Rect) TCanvas {
 	return &Canvas{
@@ -77,6 +92,61 @@ func (c *Canvas) PrintCharWithBrush(x int, y int, char rune, brush tcell.Style)
 	}
 }
 
+// PrintCellWithBrush print a charactere and his combining runes with brush.
+func (c *Canvas) PrintCellWithBrush(x int, y int, char rune, combc []rune, brush tcell.Style) {
+	// Check if out of me.
+	if InHorizontal(x, c.draw) && InVertical(y, c.draw) {
+		c.parent.PrintCellWithBrush(x+c.offset.X, y+c.offset.Y, char, combc, brush)
+	}
+}
+
+// PrintString print a string with current brush. Return width in cells.
+func (c *Canvas) PrintString(x int, y int, text string) int {
+	return printString(c, x, y, text, c.brush)
+}
+
+// PrintStringWithBrush print a string with brush. Return width in cells.
+func (c *Canvas) PrintStringWithBrush(x int, y int, text string, brush tcell.Style) int {
+	return printString(c, x, y, text, brush)
+}
+
+// MeasureString return width in cells of string.
+func (c *Canvas) MeasureString(text string) int {
+	return MeasureString(text)
+}
+
+// GetCell read cell. If out of canvas, return empty cell.
+func (c *Canvas) GetCell(x int, y int) Cell {
+	if InHorizontal(x, c.draw) && InVertical(y, c.draw) {
+		return c.parent.GetCell(x+c.offset.X, y+c.offset.Y)
+	}
+
+	return Cell{
+		Char:  ' ',
+		Style: tcell.StyleDefault,
+	}
+}
+
+// DrawHLine draw horizontal line with current brush.
+func (c *Canvas) DrawHLine(x int, y int, length int, style LineStyle) {
+	drawHLine(c, x, y, length, style, c.brush)
+}
+
+// DrawVLine draw vertical line with current brush.
+func (c *Canvas) DrawVLine(x int, y int, length int, style LineStyle) {
+	drawVLine(c, x, y, length, style, c.brush)
+}
+
+// DrawRect draw rectangle border with current brush.
+func (c *Canvas) DrawRect(r Rect, style LineStyle) {
+	drawRect(c, r, style, c.brush)
+}
+
+// DrawFrame draw rectangle border and fill inside with current brush.
+func (c *Canvas) DrawFrame(r Rect, style LineStyle) {
+	drawFrame(c, r, style, c.brush)
+}
+
 // Fill zone of canvas.
 func (c *Canvas) Fill(bounds Rect) {
 	for y := bounds.Y; y < bounds.Height; y++ {
@@ -86,6 +156,20 @@ func (c *Canvas) Fill(bounds Rect) {
 	}
 }
 
+// RootCanvas return canvas of screen: first canvas without parent. Popup uses
+// it to draw outside of its owner.
+func RootCanvas(c TCanvas) TCanvas {
+	for {
+		sub, ok := c.(*Canvas)
+
+		if !ok || sub.parent == nil {
+			return c
+		}
+
+		c = sub.parent
+	}
+}
+
 //------------------------------------------------------------------------------
 // Constrcutor.
 
@@ -102,3 +186,9 @@ func NewCanvas(parent TCanvas, r Rect) TCanvas {
 		},
 	}
 }
+
+// NewScrollCanvas create a sub canvas which content can be moved with
+// SetOrigin.
+func NewScrollCanvas(parent TCanvas, r Rect) *Canvas {
+	return NewCanvas(parent, r).(*Canvas)
+}
@@ -45,6 +45,48 @@ func (c CanvasTest) PrintCharWithBrush(x int, y int, char rune, brush tcell.Styl
 	c.screen.SetContent(x, y, char, nil, brush)
 }
 
+func (c CanvasTest) PrintCellWithBrush(x int, y int, char rune, combc []rune, brush tcell.Style) {
+	c.screen.SetContent(x, y, char, combc, brush)
+}
+
+func (c CanvasTest) PrintString(x int, y int, text string) int {
+	return printString(&c, x, y, text, c.brush)
+}
+
+func (c CanvasTest) PrintStringWithBrush(x int, y int, text string, brush tcell.Style) int {
+	return printString(&c, x, y, text, brush)
+}
+
+func (c CanvasTest) MeasureString(text string) int {
+	return MeasureString(text)
+}
+
+func (c *CanvasTest) GetCell(x int, y int) Cell {
+	mainc, combc, style, _ := c.screen.GetContent(x, y)
+
+	return Cell{
+		Char:      mainc,
+		Combining: combc,
+		Style:     style,
+	}
+}
+
+func (c *CanvasTest) DrawHLine(x int, y int, length int, style LineStyle) {
+	drawHLine(c, x, y, length, style, c.brush)
+}
+
+func (c *CanvasTest) DrawVLine(x int, y int, length int, style LineStyle) {
+	drawVLine(c, x, y, length, style, c.brush)
+}
+
+func (c *CanvasTest) DrawRect(r Rect, style LineStyle) {
+	drawRect(c, r, style, c.brush)
+}
+
+func (c *CanvasTest) DrawFrame(r Rect, style LineStyle) {
+	drawFrame(c, r, style, c.brush)
+}
+
 func (c CanvasTest) Fill(bounds Rect) {
 	for y := bounds.Y; y < bounds.Height; y++ {
 		for x := bounds.X; x < bounds.Width; x++ {
@@ -116,3 +158,22 @@ func TestCanvas_Create_sub_canvas(t *testing.T) {
 		t.Error(e)
 	}
 }
+
+func TestCanvas_SetOrigin(t *testing.T) {
+	m := NewMemoryCanvas(5, 3)
+
+	c := NewScrollCanvas(m, Rect{X: 1, Y: 1, Width: 3, Height: 1})
+	c.SetOrigin(10, 5)
+
+	if x, y := c.GetOrigin(); x != 10 || y != 5 {
+		t.Errorf("Origin must be (10, 5). Found (%d, %d)", x, y)
+	}
+
+	c.PrintString(9, 5, "abcde")
+
+	checkMemoryLines(m, []string{"     ", " bcd ", "     "}, t)
+
+	if c.GetCell(11, 5).Char != 'c' || c.GetCell(0, 0).Char != ' ' {
+		t.Error("Cell must be read in content coordinates")
+	}
+}
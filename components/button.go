@@ -0,0 +1,276 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+const (
+	// Characters for draw button shadow (like TurboVision).
+
+	// ButtonShadowTop shadow at right of first row.
+	ButtonShadowTop = '▄'
+	// ButtonShadowRight shadow at right of other rows.
+	ButtonShadowRight = '█'
+	// ButtonShadowBottom shadow under button.
+	ButtonShadowBottom = '▀'
+)
+
+// OnClick is call when button is clicked.
+type OnClick func(*Button)
+
+// Button is a push button. Click with mouse, Space or Enter when focused, or
+// Alt+mnemonic. Shadow use last column and last row of bounds.
+type Button struct {
+	// Set on window when button is clicked (ModalResultNone to not close).
+	ModalResult ModalResult
+	// Draw shadow.
+	Shadow bool
+	// Call when button is clicked.
+	OnClick OnClick
+
+	caption  string
+	mnemonic mnemonic
+	pressed  bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (b *Button) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case b.Handler():
+		b.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		b.manageMyMessage(msg)
+
+		for _, child := range b.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range b.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetCaption change text. Caption can have a mnemonic (e.g. "&OK").
+func (b *Button) SetCaption(caption string) {
+	b.caption = caption
+	b.mnemonic = parseMnemonic(caption)
+	b.Invalidate()
+}
+
+// GetCaption return text with mnemonic mark.
+func (b *Button) GetCaption() string {
+	return b.caption
+}
+
+// GetPressed return true if mouse button is down on button.
+func (b *Button) GetPressed() bool {
+	return b.pressed
+}
+
+// Click call OnClick then set modal result of window. Disabled or hidden
+// button cannot be clicked.
+func (b *Button) Click() {
+	if !b.GetEnabled() || !b.GetVisible() {
+		return
+	}
+
+	if b.OnClick != nil {
+		b.OnClick(b)
+	}
+
+	if b.ModalResult == ModalResultNone {
+		return
+	}
+
+	if w, ok := base.TopLevel(b).(*Window); ok {
+		w.SetModalResult(b.ModalResult)
+	}
+}
+
+// Draw the button.
+func (b *Button) Draw() {
+	if !b.GetVisible() {
+		return
+	}
+
+	canvas := b.Canvas()
+
+	bounds := b.GetBounds()
+	face := base.Rect{X: 0, Y: 0, Width: bounds.Width, Height: bounds.Height}
+
+	if b.Shadow && bounds.Width > 1 && bounds.Height > 1 {
+		face.Width--
+		face.Height--
+
+		b.drawShadow(canvas, bounds)
+	}
+
+	if b.pressed && face != bounds {
+		// Pressed button cover its shadow.
+		face.X++
+	}
+
+	style := b.faceStyle()
+
+	for y := face.Y; y < face.Y+face.Height; y++ {
+		for x := face.X; x < face.X+face.Width; x++ {
+			canvas.PrintCharWithBrush(x, y, ' ', style)
+		}
+	}
+
+	graphemes := base.SplitGraphemes(b.mnemonic.text)
+	width := 0
+
+	for i, g := range graphemes {
+		if width+g.Width > face.Width {
+			graphemes = graphemes[:i]
+			break
+		}
+
+		width += g.Width
+	}
+
+	x := face.X + alignText(width, face.Width, AlignCenter)
+	y := face.Y + (face.Height-1)/2
+
+	for i, g := range graphemes {
+		canvas.PrintCellWithBrush(x, y, g.Char, g.Combining, b.mnemonic.style(i, style))
+		x += g.Width
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+func (b *Button) faceStyle() tcell.Style {
+	switch {
+	case !b.GetEnabled():
+		return b.GetStyleOf(b, base.RoleButtonDisabled)
+	case b.GetFocused():
+		return b.GetStyleOf(b, base.RoleButtonFocused)
+	case b.GetHover():
+		return b.GetStyleOf(b, base.RoleButtonHover)
+	}
+
+	return b.GetStyleOf(b, base.RoleButton)
+}
+
+// Shadow is blank when button is pressed.
+func (b *Button) drawShadow(canvas base.TCanvas, bounds base.Rect) {
+	style := b.GetStyleOf(b, base.RoleButtonShadow)
+	top, right, bottom := ButtonShadowTop, ButtonShadowRight, ButtonShadowBottom
+
+	if b.pressed {
+		top, right, bottom = ' ', ' ', ' '
+	}
+
+	canvas.PrintCharWithBrush(bounds.Width-1, 0, top, style)
+
+	for y := 1; y < bounds.Height-1; y++ {
+		canvas.PrintCharWithBrush(bounds.Width-1, y, right, style)
+	}
+
+	canvas.PrintCharWithBrush(0, bounds.Height-1, ' ', style)
+
+	for x := 1; x < bounds.Width; x++ {
+		canvas.PrintCharWithBrush(x, bounds.Height-1, bottom, style)
+	}
+}
+
+// Manage message if it's for me.
+func (b *Button) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp:
+		if b.GetOnReceiveMessage() != nil && b.GetOnReceiveMessage()(b, msg) {
+			return
+		}
+
+		b.manageButtonMessage(msg)
+	case base.WmMouseLeave:
+		b.setPressed(false)
+		fallthrough
+	default:
+		msg.Handler = b.Handler()
+		b.View.HandleMessage(msg)
+	}
+}
+
+func (b *Button) manageButtonMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if b.GetOnDraw() != nil {
+			b.GetOnDraw()(b)
+		} else {
+			b.Draw()
+		}
+	case base.WmKey:
+		ev := msg.Value.(*tcell.EventKey)
+
+		if b.mnemonic.match(ev) || (b.GetFocused() && isPushKey(ev)) {
+			b.Click()
+		}
+	case base.WmLButtonDown:
+		if b.GetEnabled() {
+			b.setPressed(true)
+			base.SetFocus(b)
+		}
+	case base.WmLButtonUp:
+		if b.pressed {
+			b.setPressed(false)
+			b.Click()
+		}
+	}
+}
+
+func (b *Button) setPressed(p bool) {
+	if b.pressed != p {
+		b.pressed = p
+		b.Invalidate()
+	}
+}
+
+// Enter or Space without modifier.
+func isPushKey(ev *tcell.EventKey) bool {
+	return ev.Key() == tcell.KeyEnter ||
+		(ev.Key() == tcell.KeyRune && ev.Rune() == ' ' && ev.Modifiers() == tcell.ModNone)
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewButton create new button with shadow.
+func NewButton(name string, message base.Bus, parentCanvas base.TCanvas) Button {
+	b := Button{
+		Shadow:   true,
+		View:     base.NewView(name, message, parentCanvas),
+		mnemonic: parseMnemonic(""),
+	}
+
+	b.SetEnabled(true)
+	b.SetVisible(true)
+
+	return b
+}
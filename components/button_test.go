@@ -0,0 +1,238 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Window with button "ok" and "cancel". Return clicks count of each button.
+func createButtonTestWindow(appConfig base.ApplicationConfig) (*Window, *Button, *Button, map[string]int) {
+	clicks := make(map[string]int)
+
+	w := NewWindow("window", appConfig.Message, base.NewMemoryCanvas(20, 10))
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 20, Height: 10})
+	w.SetVisible(true)
+
+	buttons := make([]*Button, 2)
+
+	for i, name := range []string{"ok", "cancel"} {
+		b := NewButton(name, appConfig.Message, w.ClientCanvas())
+		b.SetBounds(base.Rect{X: 1, Y: 1 + i*3, Width: 8, Height: 2})
+		b.SetCaption("&" + name)
+		b.SetParent(&w)
+		b.OnClick = func(b *Button) {
+			clicks[b.Name()]++
+		}
+		w.AddChild(&b)
+
+		buttons[i] = &b
+	}
+
+	return &w, buttons[0], buttons[1], clicks
+}
+
+func TestButton_Draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(8, 2)
+
+	b := NewButton("button", appConfig.Message, m)
+	b.SetBounds(base.Rect{X: 0, Y: 0, Width: 8, Height: 2})
+	b.SetCaption("&OK")
+
+	b.HandleMessage(base.BuildDrawMessage(b.Handler()))
+
+	for y, line := range []string{"  OK   ▄", " ▀▀▀▀▀▀▀"} {
+		if l := memoryCanvasLine(m, y); l != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, line, l)
+		}
+	}
+
+	if _, _, attr := m.GetCell(2, 0).Style.Decompose(); attr&tcell.AttrUnderline == 0 {
+		t.Error("Mnemonic must be underlined")
+	}
+
+	if m.GetCell(1, 0).Style != b.GetStyle(base.RoleButton) || m.GetCell(7, 0).Style != b.GetStyle(base.RoleButtonShadow) {
+		t.Error("Wrong style of button")
+	}
+
+	b.pressed = true
+	b.Draw()
+
+	for y, line := range []string{"   OK   ", "        "} {
+		if l := memoryCanvasLine(m, y); l != line {
+			t.Errorf("Pressed line %d must be '%s'. Found '%s'", y, line, l)
+		}
+	}
+
+	b.pressed = false
+	b.HandleMessage(base.Message{Handler: b.Handler(), Type: base.WmMouseEnter})
+	b.Draw()
+
+	if m.GetCell(1, 0).Style != b.GetStyle(base.RoleButtonHover) {
+		t.Error("Button under mouse must use hover style")
+	}
+
+	b.Shadow = false
+	b.SetFocused(true)
+	b.Draw()
+
+	if l := memoryCanvasLine(m, 0); l != "   OK   " || m.GetCell(7, 1).Style != b.GetStyle(base.RoleButtonFocused) {
+		t.Errorf("Button without shadow must use all bounds. Found '%s'", l)
+	}
+
+	b.SetEnabled(false)
+	b.Draw()
+
+	if m.GetCell(0, 0).Style != b.GetStyle(base.RoleButtonDisabled) {
+		t.Error("Disabled button must use disabled style")
+	}
+}
+
+func TestButton_keys(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w, ok, _, clicks := createButtonTestWindow(appConfig)
+
+	sendKey(w, tcell.KeyRune, ' ', tcell.ModNone)
+
+	if clicks["ok"] != 0 {
+		t.Error("Space must not click button without focus")
+	}
+
+	ok.SetFocused(true)
+
+	sendKey(w, tcell.KeyRune, ' ', tcell.ModNone)
+	sendKey(w, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if clicks["ok"] != 2 {
+		t.Errorf("Space and Enter must click focused button once. Found %d", clicks["ok"])
+	}
+
+	sendKey(w, tcell.KeyRune, 'c', tcell.ModAlt)
+
+	if clicks["cancel"] != 1 {
+		t.Error("Alt+mnemonic must click button")
+	}
+
+	ok.SetEnabled(false)
+	sendKey(w, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if clicks["ok"] != 2 {
+		t.Error("Disabled button cannot be clicked")
+	}
+}
+
+func TestButton_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w, ok, cancel, clicks := createButtonTestWindow(appConfig)
+
+	click := func(side uint, x, y int) {
+		w.HandleMessage(base.BuildClickMouseMessage(w.Handler(), tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone), side))
+	}
+
+	r := base.AbsoluteBounds(ok)
+
+	click(base.WmLButtonDown, r.X, r.Y)
+
+	if !ok.GetPressed() {
+		t.Fatal("Button must be pressed")
+	}
+
+	// Focus message.
+	for len(*appConfig.Message.Channel()) > 0 {
+		if msg := <-*appConfig.Message.Channel(); msg.Type == base.WmActivate && msg.Handler != ok.Handler() {
+			t.Errorf("Only button must be activated. Found %+v", msg)
+		}
+	}
+
+	click(base.WmLButtonUp, r.X, r.Y)
+
+	if ok.GetPressed() || clicks["ok"] != 1 {
+		t.Error("Button must be clicked when mouse button is released")
+	}
+
+	r = base.AbsoluteBounds(cancel)
+
+	click(base.WmLButtonDown, r.X, r.Y)
+	cancel.HandleMessage(base.BuildMouseLeaveMessage(cancel.Handler()))
+	click(base.WmLButtonUp, r.X, r.Y)
+
+	if clicks["cancel"] != 0 {
+		t.Error("Click is canceled when mouse leave button")
+	}
+}
+
+func TestButton_default_and_cancel(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w, ok, cancel, clicks := createButtonTestWindow(appConfig)
+	w.SetDefaultButton(ok)
+	w.SetCancelButton(cancel)
+
+	sendKey(w, tcell.KeyEnter, 0, tcell.ModNone)
+	sendKey(w, tcell.KeyEscape, 0, tcell.ModNone)
+
+	if clicks["ok"] != 1 || clicks["cancel"] != 1 {
+		t.Errorf("Enter and Esc must click default and cancel buttons. Found %v", clicks)
+	}
+
+	cancel.SetFocused(true)
+	sendKey(w, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if clicks["ok"] != 1 || clicks["cancel"] != 2 {
+		t.Errorf("Enter must click focused button. Found %v", clicks)
+	}
+}
+
+func TestButton_ModalResult(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w, ok, cancel, _ := createButtonTestWindow(appConfig)
+
+	ok.ModalResult = ModalResultOK
+	cancel.ModalResult = ModalResultCancel
+
+	var result ModalResult
+
+	w.OnModalResult = func(w *Window, r ModalResult) {
+		result = r
+	}
+
+	ok.Click()
+
+	if result != ModalResultOK || w.GetModalResult() != ModalResultOK {
+		t.Errorf("Modal result must be OK. Found %d", result)
+	}
+
+	w.OnModalResult = nil
+
+	for len(*appConfig.Message.Channel()) > 0 {
+		<-*appConfig.Message.Channel()
+	}
+
+	cancel.Click()
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != base.WmDestroy || msg.Value != w || w.GetModalResult() != ModalResultCancel {
+		t.Errorf("Window must be destroyed. Found %+v", msg)
+	}
+}
@@ -0,0 +1,222 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// CheckState is state of check box.
+type CheckState int
+
+const (
+	// CheckStateUnchecked box is empty.
+	CheckStateUnchecked CheckState = iota
+	// CheckStateChecked box is checked.
+	CheckStateChecked
+	// CheckStateIndeterminate third state (e.g. some items are checked).
+	CheckStateIndeterminate
+)
+
+// OnCheckBoxChange is call when state of check box change.
+type OnCheckBoxChange func(*CheckBox)
+
+// CheckBox is a box with caption. Space, click or Alt+mnemonic toggle it.
+type CheckBox struct {
+	// Toggle use third state.
+	AllowIndeterminate bool
+	// Call when state change.
+	OnChange OnCheckBoxChange
+
+	caption  string
+	mnemonic mnemonic
+	state    CheckState
+	pressed  bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (c *CheckBox) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case c.Handler():
+		c.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		c.manageMyMessage(msg)
+
+		for _, child := range c.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range c.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetCaption change text. Caption can have a mnemonic (e.g. "&Bold").
+func (c *CheckBox) SetCaption(caption string) {
+	c.caption = caption
+	c.mnemonic = parseMnemonic(caption)
+	c.Invalidate()
+}
+
+// GetCaption return text with mnemonic mark.
+func (c *CheckBox) GetCaption() string {
+	return c.caption
+}
+
+// SetState change state. OnChange is call if state change.
+func (c *CheckBox) SetState(s CheckState) {
+	if s == c.state {
+		return
+	}
+
+	c.state = s
+	c.Invalidate()
+
+	if c.OnChange != nil {
+		c.OnChange(c)
+	}
+}
+
+// GetState return state.
+func (c *CheckBox) GetState() CheckState {
+	return c.state
+}
+
+// SetChecked check or uncheck box.
+func (c *CheckBox) SetChecked(checked bool) {
+	if checked {
+		c.SetState(CheckStateChecked)
+	} else {
+		c.SetState(CheckStateUnchecked)
+	}
+}
+
+// GetChecked return true if box is checked.
+func (c *CheckBox) GetChecked() bool {
+	return c.state == CheckStateChecked
+}
+
+// Toggle go to next state: unchecked, checked, indeterminate (if allowed).
+func (c *CheckBox) Toggle() {
+	switch {
+	case c.state == CheckStateUnchecked:
+		c.SetState(CheckStateChecked)
+	case c.state == CheckStateChecked && c.AllowIndeterminate:
+		c.SetState(CheckStateIndeterminate)
+	default:
+		c.SetState(CheckStateUnchecked)
+	}
+}
+
+// Draw the check box.
+func (c *CheckBox) Draw() {
+	if !c.GetVisible() {
+		return
+	}
+
+	mark := ' '
+
+	switch c.state {
+	case CheckStateChecked:
+		mark = CheckBoxChecked
+	case CheckStateIndeterminate:
+		mark = CheckBoxIndeterminate
+	}
+
+	drawClusterItem(c.Canvas(), 0, c.GetBounds().Width, "["+string(mark)+"] ", c.mnemonic,
+		clusterStyle(c, c.GetFocused()))
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (c *CheckBox) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp:
+		if c.GetOnReceiveMessage() != nil && c.GetOnReceiveMessage()(c, msg) {
+			return
+		}
+
+		c.manageCheckBoxMessage(msg)
+	case base.WmMouseLeave:
+		c.pressed = false
+		fallthrough
+	default:
+		msg.Handler = c.Handler()
+		c.View.HandleMessage(msg)
+	}
+}
+
+func (c *CheckBox) manageCheckBoxMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if c.GetOnDraw() != nil {
+			c.GetOnDraw()(c)
+		} else {
+			c.Draw()
+		}
+
+		return
+	}
+
+	if !c.GetEnabled() || !c.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		ev := msg.Value.(*tcell.EventKey)
+
+		if c.mnemonic.match(ev) {
+			base.SetFocus(c)
+			c.Toggle()
+		} else if c.GetFocused() && isSpaceKey(ev) {
+			c.Toggle()
+		}
+	case base.WmLButtonDown:
+		c.pressed = true
+		base.SetFocus(c)
+	case base.WmLButtonUp:
+		if c.pressed {
+			c.pressed = false
+			c.Toggle()
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewCheckBox create new unchecked check box.
+func NewCheckBox(name string, message base.Bus, parentCanvas base.TCanvas) CheckBox {
+	c := CheckBox{
+		View:     base.NewView(name, message, parentCanvas),
+		mnemonic: parseMnemonic(""),
+	}
+
+	c.SetEnabled(true)
+	c.SetVisible(true)
+
+	return c
+}
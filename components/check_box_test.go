@@ -0,0 +1,145 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func TestCheckBox_Toggle(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	c := NewCheckBox("check", appConfig.Message, base.NewMemoryCanvas(10, 1))
+
+	var states []CheckState
+
+	c.OnChange = func(c *CheckBox) {
+		states = append(states, c.GetState())
+	}
+
+	c.Toggle()
+	c.Toggle()
+	c.AllowIndeterminate = true
+	c.Toggle()
+	c.Toggle()
+	c.Toggle()
+	c.SetChecked(false)
+
+	want := []CheckState{CheckStateChecked, CheckStateUnchecked, CheckStateChecked, CheckStateIndeterminate,
+		CheckStateUnchecked}
+
+	if len(states) != len(want) {
+		t.Fatalf("States must be %v. Found %v", want, states)
+	}
+
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("States must be %v. Found %v", want, states)
+		}
+	}
+
+	if c.GetChecked() {
+		t.Error("SetChecked of same state must not call OnChange")
+	}
+}
+
+func TestCheckBox_Draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(12, 1)
+
+	c := NewCheckBox("check", appConfig.Message, m)
+	c.SetBounds(base.Rect{X: 0, Y: 0, Width: 12, Height: 1})
+	c.SetCaption("&Bold")
+
+	tests := map[CheckState]string{
+		CheckStateUnchecked:     "[ ] Bold    ",
+		CheckStateChecked:       "[X] Bold    ",
+		CheckStateIndeterminate: "[■] Bold    ",
+	}
+
+	for state, line := range tests {
+		c.SetState(state)
+		c.HandleMessage(base.BuildDrawMessage(c.Handler()))
+
+		if l := memoryCanvasLine(m, 0); l != line {
+			t.Errorf("State %d must be '%s'. Found '%s'", state, line, l)
+		}
+	}
+
+	if _, _, attr := m.GetCell(4, 0).Style.Decompose(); attr&tcell.AttrUnderline == 0 {
+		t.Error("Mnemonic must be underlined")
+	}
+
+	c.SetFocused(true)
+	c.Draw()
+
+	if m.GetCell(11, 0).Style != c.GetStyle(base.RoleClusterFocused) {
+		t.Error("Focused check box must use focused style")
+	}
+}
+
+func TestCheckBox_keys_and_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w, _, _, _ := createButtonTestWindow(appConfig)
+
+	c := NewCheckBox("check", appConfig.Message, w.ClientCanvas())
+	c.SetBounds(base.Rect{X: 10, Y: 1, Width: 8, Height: 1})
+	c.SetCaption("&Bold")
+	c.SetParent(w)
+	w.AddChild(&c)
+
+	sendKey(w, tcell.KeyRune, ' ', tcell.ModNone)
+
+	if c.GetChecked() {
+		t.Error("Space must not toggle check box without focus")
+	}
+
+	sendKey(w, tcell.KeyRune, 'b', tcell.ModAlt)
+
+	if !c.GetChecked() {
+		t.Error("Alt+mnemonic must toggle check box")
+	}
+
+	c.SetFocused(true)
+	sendKey(w, tcell.KeyRune, ' ', tcell.ModNone)
+
+	if c.GetChecked() {
+		t.Error("Space must toggle focused check box")
+	}
+
+	// Click on label.
+	r := base.AbsoluteBounds(&c)
+
+	for _, side := range []uint{base.WmLButtonDown, base.WmLButtonUp} {
+		ev := tcell.NewEventMouse(r.X+6, r.Y, tcell.ButtonNone, tcell.ModNone)
+		w.HandleMessage(base.BuildClickMouseMessage(w.Handler(), ev, side))
+	}
+
+	if !c.GetChecked() {
+		t.Error("Click on label must toggle check box")
+	}
+
+	c.SetEnabled(false)
+	sendKey(w, tcell.KeyRune, 'b', tcell.ModAlt)
+
+	if !c.GetChecked() {
+		t.Error("Disabled check box cannot be toggled")
+	}
+}
@@ -0,0 +1,67 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Characters for draw check boxes and radio buttons.
+const (
+	// CheckBoxChecked mark of checked box.
+	CheckBoxChecked = 'X'
+	// CheckBoxIndeterminate mark of box in third state.
+	CheckBoxIndeterminate = '■'
+	// RadioButtonChecked mark of checked radio button.
+	RadioButtonChecked = '•'
+)
+
+// View that can return style of role.
+type styledView interface {
+	base.TView
+	GetStyleOf(base.TComponent, base.StyleRole) tcell.Style
+}
+
+// Style of check box, radio button or item of radio group.
+func clusterStyle(v styledView, focused bool) tcell.Style {
+	switch {
+	case !v.GetEnabled():
+		return v.GetStyleOf(v, base.RoleDisabled)
+	case focused:
+		return v.GetStyleOf(v, base.RoleClusterFocused)
+	}
+
+	return v.GetStyleOf(v, base.RoleCluster)
+}
+
+// Draw box like "[X] " then caption at line `y`. Line is filled with style.
+func drawClusterItem(canvas base.TCanvas, y int, width int, box string, m mnemonic, style tcell.Style) {
+	for x := 0; x < width; x++ {
+		canvas.PrintCharWithBrush(x, y, ' ', style)
+	}
+
+	x := canvas.PrintStringWithBrush(0, y, box, style)
+
+	for i, g := range base.SplitGraphemes(m.text) {
+		canvas.PrintCellWithBrush(x, y, g.Char, g.Combining, m.style(i, style))
+		x += g.Width
+	}
+}
+
+// Space without modifier.
+func isSpaceKey(ev *tcell.EventKey) bool {
+	return ev.Key() == tcell.KeyRune && ev.Rune() == ' ' && ev.Modifiers() == tcell.ModNone
+}
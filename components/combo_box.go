@@ -0,0 +1,534 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// ComboBoxArrow is draw in last cell of combo box.
+const ComboBoxArrow = '▼'
+
+// DefaultDropDownCount is maximum number of visible items in drop-down list.
+const DefaultDropDownCount = 8
+
+// OnComboBoxChange is call when text or selected item of combo box change.
+type OnComboBoxChange func(*ComboBox)
+
+// ComboBox is an input line with a drop-down list. In editable mode, typed
+// text filters items of list. In drop-down only mode, text is selected item
+// and typing search item.
+//
+// Keys: Alt+Down or F4 open and close list, Up and Down select previous and
+// next item, Enter choose item of opened list, Esc close list.
+type ComboBox struct {
+	// Maximum number of visible items in drop-down list.
+	DropDownCount int
+	// Call when text or selected item change.
+	OnChange OnComboBoxChange
+
+	items     []string
+	filtered  []int
+	itemIndex int
+	editable  bool
+	updating  bool
+	edit      *InputLine
+	popup     *Popup
+	list      *ListBox
+
+	base.View
+}
+
+// Items of drop-down list: items matching text.
+type comboBoxItems struct {
+	c *ComboBox
+}
+
+func (i comboBoxItems) Count() int {
+	return len(i.c.filtered)
+}
+
+func (i comboBoxItems) Item(n int) string {
+	return i.c.items[i.c.filtered[n]]
+}
+
+// HandleMessage is use to manage message.
+func (c *ComboBox) HandleMessage(msg base.Message) bool {
+	c.bind()
+
+	switch msg.Handler {
+	case c.Handler():
+		c.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		c.manageMyMessage(msg)
+
+		for _, child := range c.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range c.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+
+	}
+
+	return false
+}
+
+// SetBounds set view size. Height is 1.
+func (c *ComboBox) SetBounds(r base.Rect) {
+	r.Height = 1
+
+	c.View.SetBounds(r)
+	c.layout()
+}
+
+// SetItems change items of list. Selected item is removed.
+func (c *ComboBox) SetItems(items []string) {
+	c.items = items
+	c.itemIndex = -1
+	c.CloseUp()
+	c.setText("")
+}
+
+// GetItems return items of list.
+func (c *ComboBox) GetItems() []string {
+	return c.items
+}
+
+// SetItemIndex select item and change text. -1 remove selection. Invalid
+// index is ignored.
+func (c *ComboBox) SetItemIndex(i int) {
+	if i < -1 || i >= len(c.items) || i == c.itemIndex {
+		return
+	}
+
+	c.itemIndex = i
+
+	if i >= 0 {
+		c.setText(c.items[i])
+	} else {
+		c.setText("")
+	}
+
+	c.change()
+}
+
+// GetItemIndex return selected item or -1 if text is not an item.
+func (c *ComboBox) GetItemIndex() int {
+	return c.itemIndex
+}
+
+// SetText change text. Selected item is item equals to text. In drop-down
+// only mode, text must be an item.
+func (c *ComboBox) SetText(text string) {
+	if i := c.indexOf(text); i >= 0 || c.editable {
+		c.itemIndex = i
+		c.setText(text)
+		c.change()
+	}
+}
+
+// GetText return text.
+func (c *ComboBox) GetText() string {
+	return c.edit.GetText()
+}
+
+// SetEditable allow to type any text. Otherwise, only items can be selected.
+func (c *ComboBox) SetEditable(e bool) {
+	c.editable = e
+	c.edit.SetVisible(e)
+	c.Invalidate()
+}
+
+// GetEditable return true if any text can be typed.
+func (c *ComboBox) GetEditable() bool {
+	return c.editable
+}
+
+// DropDown open drop-down list with all items under combo box.
+func (c *ComboBox) DropDown() {
+	c.bind()
+
+	if !c.GetEnabled() || len(c.items) == 0 {
+		return
+	}
+
+	c.filter("")
+	c.popup.Open()
+}
+
+// CloseUp close drop-down list.
+func (c *ComboBox) CloseUp() {
+	c.popup.Close()
+}
+
+// GetDroppedDown return true if drop-down list is open.
+func (c *ComboBox) GetDroppedDown() bool {
+	return c.popup.GetOpened()
+}
+
+// Draw the combo box. In editable mode, text is draw by input line.
+func (c *ComboBox) Draw() {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.bind()
+	c.layout()
+
+	canvas := c.Canvas()
+	width := c.GetBounds().Width
+
+	if !c.editable {
+		style := c.GetStyleOf(c, base.RoleInput)
+
+		switch {
+		case !c.GetEnabled():
+			style = c.GetStyleOf(c, base.RoleDisabled)
+		case c.GetFocused():
+			style = c.GetStyleOf(c, base.RoleInputSelection)
+		}
+
+		for x := 0; x < width-1; x++ {
+			canvas.PrintCharWithBrush(x, 0, ' ', style)
+		}
+
+		x := 0
+
+		for _, g := range base.SplitGraphemes(base.TruncateString(c.GetText(), width-1)) {
+			canvas.PrintCellWithBrush(x, 0, g.Char, g.Combining, style)
+			x += g.Width
+		}
+	}
+
+	arrowStyle := c.GetStyleOf(c, base.RoleButton)
+
+	if !c.GetEnabled() {
+		arrowStyle = c.GetStyleOf(c, base.RoleButtonDisabled)
+	}
+
+	canvas.PrintCharWithBrush(width-1, 0, ComboBoxArrow, arrowStyle)
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (c *ComboBox) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown:
+		if c.GetOnReceiveMessage() != nil && c.GetOnReceiveMessage()(c, msg) {
+			return
+		}
+
+		c.manageComboBoxMessage(msg)
+	case base.WmActivate:
+		// Input line shows caret when combo box has focus.
+		c.edit.SetFocused(msg.Value == base.WaActive)
+		c.edit.Invalidate()
+		c.Invalidate()
+
+		if msg.Value != base.WaActive {
+			c.CloseUp()
+		}
+
+		fallthrough
+	default:
+		msg.Handler = c.Handler()
+		c.View.HandleMessage(msg)
+	}
+}
+
+func (c *ComboBox) manageComboBoxMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if c.GetOnDraw() != nil {
+			c.GetOnDraw()(c)
+		} else {
+			c.Draw()
+
+			for _, child := range c.Children() {
+				child.HandleMessage(base.BuildDrawMessage(child.Handler()))
+			}
+		}
+
+		return
+	}
+
+	if !c.GetEnabled() || !c.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		if c.GetFocused() {
+			c.manageKey(msg.Value.(*tcell.EventKey))
+		}
+	case base.WmLButtonDown:
+		// Click on arrow or on text in drop-down only mode.
+		c.focus()
+
+		if c.GetDroppedDown() {
+			c.CloseUp()
+		} else {
+			c.DropDown()
+		}
+	}
+}
+
+func (c *ComboBox) manageKey(ev *tcell.EventKey) {
+	dropped := c.GetDroppedDown()
+
+	switch ev.Key() {
+	case tcell.KeyF4:
+		c.toggle()
+	case tcell.KeyDown, tcell.KeyUp, tcell.KeyPgDn, tcell.KeyPgUp:
+		switch {
+		case ev.Key() == tcell.KeyDown && ev.Modifiers()&tcell.ModAlt != 0:
+			c.toggle()
+		case dropped:
+			c.list.HandleMessage(base.Message{Handler: c.list.Handler(), Type: base.WmKey, Value: ev})
+		case ev.Key() == tcell.KeyDown:
+			c.SetItemIndex(base.MinInt(c.itemIndex+1, len(c.items)-1))
+		case ev.Key() == tcell.KeyUp && c.itemIndex > 0:
+			c.SetItemIndex(c.itemIndex - 1)
+		}
+	case tcell.KeyEnter:
+		if dropped {
+			c.choose(c.list.GetItemIndex())
+		}
+	case tcell.KeyEscape:
+		c.CloseUp()
+	case tcell.KeyRune:
+		switch {
+		case c.editable || ev.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0:
+			// Input line manage typed text.
+		case dropped:
+			// Search item in list.
+			c.list.HandleMessage(base.Message{Handler: c.list.Handler(), Type: base.WmKey, Value: ev})
+		default:
+			c.SetItemIndex(c.nextItem(string(ev.Rune())))
+		}
+	case tcell.KeyHome, tcell.KeyEnd:
+		if dropped && !c.editable {
+			c.list.HandleMessage(base.Message{Handler: c.list.Handler(), Type: base.WmKey, Value: ev})
+		}
+	}
+}
+
+func (c *ComboBox) toggle() {
+	if c.GetDroppedDown() {
+		c.CloseUp()
+	} else {
+		c.DropDown()
+	}
+}
+
+// Bind input line and drop-down list to combo box. Done on first use, when
+// combo box is at its final address.
+func (c *ComboBox) bind() {
+	if c.edit.GetParent() == base.TComponent(c) {
+		return
+	}
+
+	c.edit.SetParent(c)
+	c.edit.OnChange = c.editChange
+	c.edit.SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		if msg.Type == base.WmLButtonDown && c.GetEnabled() {
+			c.focus()
+		}
+
+		return false
+	})
+
+	c.popup.SetOwner(c)
+	c.list.SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		if msg.Type == base.WmLButtonUp {
+			if n := c.list.itemAt(msg.Value.(*tcell.EventMouse).Position()); n >= 0 {
+				c.choose(n)
+			}
+		}
+
+		return false
+	})
+}
+
+// Input line use all width except arrow.
+func (c *ComboBox) layout() {
+	bounds := base.Rect{X: 0, Y: 0, Width: base.MaxInt(c.GetBounds().Width-1, 0), Height: 1}
+
+	if c.edit.GetBounds() != bounds {
+		c.edit.SetBounds(bounds)
+	}
+}
+
+// Return next item after selected item starting with prefix or selected
+// item.
+func (c *ComboBox) nextItem(prefix string) int {
+	prefix = strings.ToLower(prefix)
+
+	for n := 1; n <= len(c.items); n++ {
+		i := (c.itemIndex + n + len(c.items)) % len(c.items)
+
+		if strings.HasPrefix(strings.ToLower(c.items[i]), prefix) {
+			return i
+		}
+	}
+
+	return c.itemIndex
+}
+
+// Combo box and its input line have focus.
+func (c *ComboBox) focus() {
+	if !c.GetFocused() {
+		base.SetFocus(c)
+	}
+
+	c.edit.SetFocused(true)
+}
+
+// Select item n of drop-down list and close it.
+func (c *ComboBox) choose(n int) {
+	if n >= 0 && n < len(c.filtered) {
+		i := c.filtered[n]
+
+		// Same item can be typed with other case.
+		c.itemIndex = -1
+		c.SetItemIndex(i)
+	}
+
+	c.CloseUp()
+}
+
+// Show items starting with text in drop-down list. Current item of list is
+// selected item.
+func (c *ComboBox) filter(text string) {
+	prefix := strings.ToLower(text)
+	c.filtered = c.filtered[:0]
+
+	for i, item := range c.items {
+		if strings.HasPrefix(strings.ToLower(item), prefix) {
+			c.filtered = append(c.filtered, i)
+		}
+	}
+
+	old := base.PaintBounds(c.popup)
+	bounds := base.AbsoluteBounds(c)
+
+	c.popup.SetBounds(base.Rect{
+		X:      bounds.X,
+		Y:      bounds.Y + 1,
+		Width:  bounds.Width,
+		Height: base.MinInt(len(c.filtered), base.MaxInt(c.DropDownCount, 1)),
+	})
+	c.list.SetBounds(base.Rect{X: 0, Y: 0, Width: bounds.Width, Height: c.popup.GetBounds().Height})
+	c.list.SetDataSource(comboBoxItems{c: c})
+
+	for n, i := range c.filtered {
+		if i == c.itemIndex {
+			c.list.SetItemIndex(n)
+		}
+	}
+
+	if c.popup.GetOpened() {
+		// Size can change.
+		c.GetMessageBus().Send(base.BuildInvalidateMessage(old))
+		c.popup.Invalidate()
+	}
+}
+
+// Typed text filters items.
+func (c *ComboBox) editChange(*InputLine) {
+	if c.updating {
+		return
+	}
+
+	c.itemIndex = c.indexOf(c.GetText())
+
+	c.filter(c.GetText())
+
+	if len(c.filtered) > 0 && c.GetFocused() {
+		c.popup.Open()
+	} else {
+		c.CloseUp()
+	}
+
+	c.change()
+}
+
+// Change text without filtering.
+func (c *ComboBox) setText(text string) {
+	c.updating = true
+	c.edit.SetText(text)
+	c.updating = false
+
+	c.Invalidate()
+}
+
+// Return index of item equals to text (case insensitive) or -1.
+func (c *ComboBox) indexOf(text string) int {
+	for i, item := range c.items {
+		if strings.EqualFold(item, text) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (c *ComboBox) change() {
+	if c.OnChange != nil {
+		c.OnChange(c)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewComboBox create new editable combo box without items.
+func NewComboBox(name string, message base.Bus, parentCanvas base.TCanvas) ComboBox {
+	c := ComboBox{
+		DropDownCount: DefaultDropDownCount,
+		itemIndex:     -1,
+		editable:      true,
+		View:          base.NewView(name, message, parentCanvas),
+	}
+
+	c.SetEnabled(true)
+	c.SetVisible(true)
+
+	edit := NewInputLine(name+".edit", message, c.Canvas())
+	c.edit = &edit
+	c.AddChild(c.edit)
+
+	// Drop-down list is draw outside of parent.
+	popup := NewPopup(name+".popup", message, base.RootCanvas(parentCanvas))
+	c.popup = &popup
+
+	list := NewListBox(name+".list", message, c.popup.Canvas())
+	c.list = &list
+	c.list.SetParent(c.popup)
+	c.list.SetFocused(true)
+	c.popup.AddChild(c.list)
+
+	return c
+}
@@ -0,0 +1,222 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func createTestComboBox(appConfig base.ApplicationConfig, m *base.MemoryCanvas) *ComboBox {
+	c := NewComboBox("combo", appConfig.Message, m)
+	c.SetBounds(base.Rect{X: 1, Y: 1, Width: 8, Height: 1})
+	c.SetItems([]string{"Apple", "Apricot", "Banana", "Cherry"})
+	c.HandleMessage(base.BuildActivateMessage(c.Handler()))
+
+	return &c
+}
+
+func TestComboBox_filter(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	c := createTestComboBox(appConfig, base.NewMemoryCanvas(10, 10))
+
+	changes := 0
+	c.OnChange = func(*ComboBox) {
+		changes++
+	}
+
+	typeText(c, "ap")
+
+	if !c.GetDroppedDown() || c.list.Count() != 2 || c.GetItemIndex() != -1 || changes != 2 {
+		t.Errorf("Typed text must filter items. Found %v, %d items, index %d, %d changes",
+			c.GetDroppedDown(), c.list.Count(), c.GetItemIndex(), changes)
+	}
+
+	if b := c.popup.GetBounds(); b != (base.Rect{X: 1, Y: 2, Width: 8, Height: 2}) {
+		t.Errorf("Drop-down list must be under combo box. Found %v", b)
+	}
+
+	sendKey(c, tcell.KeyDown, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if c.GetDroppedDown() || c.GetText() != "Apricot" || c.GetItemIndex() != 1 {
+		t.Errorf("Enter must choose current item. Found %v, '%s', %d", c.GetDroppedDown(), c.GetText(), c.GetItemIndex())
+	}
+
+	typeText(c, "x")
+
+	if c.GetDroppedDown() || c.GetItemIndex() != -1 || c.GetText() != "Apricotx" {
+		t.Error("Any text can be typed and list closes if no item matches")
+	}
+
+	c.SetText("cherry")
+
+	if c.GetItemIndex() != 3 || c.GetDroppedDown() {
+		t.Errorf("Text must select item. Found %d", c.GetItemIndex())
+	}
+}
+
+func TestComboBox_keyboard(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	c := createTestComboBox(appConfig, base.NewMemoryCanvas(10, 10))
+
+	sendKey(c, tcell.KeyDown, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyDown, 0, tcell.ModNone)
+
+	if c.GetItemIndex() != 1 || c.GetText() != "Apricot" || c.GetDroppedDown() {
+		t.Errorf("Down must select next item. Found %d", c.GetItemIndex())
+	}
+
+	sendKey(c, tcell.KeyDown, 0, tcell.ModAlt)
+
+	if !c.GetDroppedDown() || c.list.Count() != 4 || c.list.GetItemIndex() != 1 {
+		t.Error("Alt+Down must open list with all items on selected item")
+	}
+
+	sendKey(c, tcell.KeyUp, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyEscape, 0, tcell.ModNone)
+
+	if c.GetDroppedDown() || c.GetItemIndex() != 1 {
+		t.Error("Esc must close list without changing item")
+	}
+
+	sendKey(c, tcell.KeyF4, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyUp, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if c.GetDroppedDown() || c.GetItemIndex() != 0 {
+		t.Errorf("Enter must choose item. Found %d", c.GetItemIndex())
+	}
+}
+
+func TestComboBox_drop_down_only(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 10)
+	c := createTestComboBox(appConfig, m)
+	c.SetEditable(false)
+
+	c.SetText("Orange")
+
+	if c.GetText() != "" {
+		t.Error("Text must be an item")
+	}
+
+	typeText(c, "a")
+	typeText(c, "a")
+
+	if c.GetItemIndex() != 1 || c.GetDroppedDown() {
+		t.Errorf("Typed char must select next matching item. Found %d", c.GetItemIndex())
+	}
+
+	typeText(c, "a")
+
+	if c.GetItemIndex() != 0 {
+		t.Errorf("Search must restart from first item. Found %d", c.GetItemIndex())
+	}
+
+	c.HandleMessage(base.BuildDrawMessage(c.Handler()))
+
+	if s := memoryCanvasLine(m, 1); s != " Apple  ▼ " {
+		t.Errorf("Combo box must draw selected item. Found '%s'", s)
+	}
+
+	// Click on text open list.
+	ev := tcell.NewEventMouse(2, 1, tcell.Button1, tcell.ModNone)
+	c.HandleMessage(base.BuildClickMouseMessage(c.Handler(), ev, base.WmLButtonDown))
+
+	if !c.GetDroppedDown() {
+		t.Error("Click must open list")
+	}
+
+	sendKey(c, tcell.KeyEnd, 0, tcell.ModNone)
+	sendKey(c, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if c.GetItemIndex() != 3 {
+		t.Errorf("End must go to last item. Found %d", c.GetItemIndex())
+	}
+}
+
+func TestComboBox_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	c := createTestComboBox(appConfig, base.NewMemoryCanvas(10, 10))
+
+	// Click on arrow.
+	ev := tcell.NewEventMouse(8, 1, tcell.Button1, tcell.ModNone)
+	c.HandleMessage(base.BuildClickMouseMessage(c.Handler(), ev, base.WmLButtonDown))
+
+	if !c.GetDroppedDown() {
+		t.Fatal("Click on arrow must open list")
+	}
+
+	// Click on third item.
+	for _, msgType := range []uint{base.WmLButtonDown, base.WmLButtonUp} {
+		ev = tcell.NewEventMouse(3, 4, tcell.Button1, tcell.ModNone)
+		c.popup.HandleMessage(base.BuildClickMouseMessage(c.popup.Handler(), ev, msgType))
+	}
+
+	if c.GetDroppedDown() || c.GetText() != "Banana" {
+		t.Errorf("Click on item must choose it. Found '%s'", c.GetText())
+	}
+
+	ev = tcell.NewEventMouse(8, 1, tcell.Button1, tcell.ModNone)
+	c.HandleMessage(base.BuildClickMouseMessage(c.Handler(), ev, base.WmLButtonDown))
+	c.popup.HandleMessage(base.BuildClosePopupMessage(c.popup.Handler()))
+
+	if c.GetDroppedDown() || c.GetText() != "Banana" {
+		t.Error("Outside click must close list")
+	}
+}
+
+func TestComboBox_window_keys(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w := NewWindow("window", appConfig.Message, base.NewMemoryCanvas(20, 10))
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 20, Height: 10})
+
+	c := createTestComboBox(appConfig, base.NewMemoryCanvas(10, 10))
+	c.SetParent(&w)
+	w.AddChild(c)
+
+	if c.edit.GetParent() != base.TComponent(c) || c.popup.GetOwner() != base.TView(c) {
+		t.Error("Children must be bound to combo box when it is added to window")
+	}
+
+	b := NewButton("ok", appConfig.Message, w.ClientCanvas())
+	w.SetDefaultButton(&b)
+
+	clicked := 0
+	b.OnClick = func(*Button) {
+		clicked++
+	}
+
+	sendKey(c, tcell.KeyF4, 0, tcell.ModNone)
+	sendKey(&w, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if clicked != 0 || c.GetDroppedDown() {
+		t.Error("Enter must close list, not click default button")
+	}
+
+	sendKey(&w, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if clicked != 1 {
+		t.Error("Enter must click default button when list is closed")
+	}
+}
@@ -0,0 +1,63 @@
+package components_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/components"
+	"github.com/emeric-martineau/govision/govisiontest"
+)
+
+func TestDialog_close_by_button(t *testing.T) {
+	h := govisiontest.NewHarness(t, 30, 10)
+	defer h.Close()
+
+	main := components.NewWindow("main", h.Config.Message, h.App.Canvas())
+	main.SetBounds(base.Rect{X: 0, Y: 0, Width: 20, Height: 8})
+	main.SetVisible(true)
+	main.SetEnabled(true)
+
+	dialog := components.NewWindow("dialog", h.Config.Message, h.App.Canvas())
+	dialog.SetBounds(base.Rect{X: 8, Y: 2, Width: 18, Height: 6})
+	dialog.SetVisible(true)
+	dialog.SetEnabled(true)
+	dialog.Shadow = true
+
+	ok := components.NewButton("ok", h.Config.Message, dialog.ClientCanvas())
+	ok.SetBounds(base.Rect{X: 1, Y: 1, Width: 8, Height: 2})
+	ok.SetCaption("&ok")
+	ok.SetParent(&dialog)
+	ok.SetVisible(true)
+	ok.SetEnabled(true)
+	ok.ModalResult = components.ModalResultOK
+	dialog.AddChild(&ok)
+
+	h.App.AddWindow(&main)
+	h.App.AddWindow(&dialog)
+
+	h.Start()
+
+	h.AssertGolden("testdata/dialog_open.golden")
+
+	h.ClickOn("ok")
+
+	if dialog.GetModalResult() != components.ModalResultOK {
+		t.Errorf("Modal result must be OK. Found %d", dialog.GetModalResult())
+	}
+
+	h.AssertGolden("testdata/dialog_closed.golden")
+}
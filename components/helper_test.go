@@ -0,0 +1,40 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func keyEvent(k tcell.Key, r rune, mod tcell.ModMask) *tcell.EventKey {
+	return tcell.NewEventKey(k, r, mod)
+}
+
+// Send key to component like application does.
+func sendKey(c base.TComponent, k tcell.Key, r rune, mod tcell.ModMask) bool {
+	return c.HandleMessage(base.BuildKeyMessage(keyEvent(k, r, mod)))
+}
+
+// Send each rune of text. New line is Enter key.
+func typeText(c base.TComponent, text string) {
+	for _, r := range text {
+		if r == '\n' {
+			sendKey(c, tcell.KeyEnter, 0, tcell.ModNone)
+		} else {
+			sendKey(c, tcell.KeyRune, r, tcell.ModNone)
+		}
+	}
+}
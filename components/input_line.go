@@ -0,0 +1,548 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+	"unicode"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// OnInputLineChange is call when text of input line change.
+type OnInputLineChange func(*InputLine)
+
+// OnInputLineSubmit is call when Enter is pressed in input line.
+type OnInputLineSubmit func(*InputLine)
+
+// InputLine is a single line text editor. Text scrolls horizontally when it
+// is longer than view. Position of caret and selection are index of
+// characters (graphemes).
+//
+// Keys: arrows, Home, End (Shift to select, Ctrl to jump words), Backspace,
+// Delete, Insert (overwrite mode), Ctrl+A (select all), Ctrl+X or
+// Shift+Delete (cut), Ctrl+C or Ctrl+Insert (copy), Ctrl+V or Shift+Insert
+// (paste). Ctrl+C quit application if ExitOnCtrlC is set.
+type InputLine struct {
+	// Maximum number of characters. 0 for no limit.
+	MaxLength int
+	// Call when text change.
+	OnChange OnInputLineChange
+	// Call when Enter is pressed.
+	OnSubmit OnInputLineSubmit
+
+	text      []base.Grapheme
+	caret     int
+	anchor    int
+	offset    int
+	overwrite bool
+	selecting bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (i *InputLine) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case i.Handler():
+		i.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		i.manageMyMessage(msg)
+
+		for _, child := range i.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range i.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetText replace text, move caret at end and remove selection. Text is cut
+// at first line break and at MaxLength.
+func (i *InputLine) SetText(text string) {
+	old := i.GetText()
+
+	i.text = i.limit(splitLine(text), 0)
+	i.caret = len(i.text)
+	i.anchor = i.caret
+	i.offset = 0
+	i.scroll()
+	i.Invalidate()
+
+	if old != i.GetText() {
+		i.change()
+	}
+}
+
+// GetText return text.
+func (i *InputLine) GetText() string {
+	return joinGraphemes(i.text)
+}
+
+// SetCaret move caret and remove selection.
+func (i *InputLine) SetCaret(pos int) {
+	i.moveCaret(pos, false)
+}
+
+// GetCaret return caret position.
+func (i *InputLine) GetCaret() int {
+	return i.caret
+}
+
+// SetSelection select characters from start to end (excluded). Caret is moved
+// at end.
+func (i *InputLine) SetSelection(start, end int) {
+	i.anchor = i.clamp(start)
+	i.moveCaret(end, true)
+}
+
+// GetSelection return first and last (excluded) selected characters. Start
+// equals end if there is no selection.
+func (i *InputLine) GetSelection() (int, int) {
+	return base.MinInt(i.anchor, i.caret), base.MaxInt(i.anchor, i.caret)
+}
+
+// GetSelectedText return selected text.
+func (i *InputLine) GetSelectedText() string {
+	start, end := i.GetSelection()
+
+	return joinGraphemes(i.text[start:end])
+}
+
+// SelectAll select all text.
+func (i *InputLine) SelectAll() {
+	i.SetSelection(0, len(i.text))
+}
+
+// SetOverwrite change mode. In overwrite mode, typed characters replace
+// characters after caret.
+func (i *InputLine) SetOverwrite(o bool) {
+	i.overwrite = o
+}
+
+// GetOverwrite return true in overwrite mode.
+func (i *InputLine) GetOverwrite() bool {
+	return i.overwrite
+}
+
+// Insert text at caret like it is typed. Selection is replaced.
+func (i *InputLine) Insert(text string) {
+	graphemes := splitLine(text)
+
+	start, end := i.GetSelection()
+
+	if start == end && i.overwrite {
+		end = base.MinInt(start+len(graphemes), len(i.text))
+	}
+
+	graphemes = i.limit(graphemes, len(i.text)-(end-start))
+
+	if start == end && len(graphemes) == 0 {
+		return
+	}
+
+	i.replace(start, end, graphemes)
+}
+
+// DeleteSelection remove selected text.
+func (i *InputLine) DeleteSelection() {
+	if start, end := i.GetSelection(); start != end {
+		i.replace(start, end, nil)
+	}
+}
+
+// CopyToClipboard put selected text in application clipboard.
+func (i *InputLine) CopyToClipboard() {
+	if start, end := i.GetSelection(); start != end {
+		i.GetMessageBus().Send(base.BuildSetClipboardMessage(i.GetSelectedText()))
+	}
+}
+
+// CutToClipboard put selected text in application clipboard and remove it.
+func (i *InputLine) CutToClipboard() {
+	i.CopyToClipboard()
+	i.DeleteSelection()
+}
+
+// PasteFromClipboard ask clipboard to application. Text is inserted when
+// application answers.
+func (i *InputLine) PasteFromClipboard() {
+	i.GetMessageBus().Send(base.BuildGetClipboardMessage(i.Handler()))
+}
+
+// Draw the input line. Text cursor is shown when input line and its window
+// have focus.
+func (i *InputLine) Draw() {
+	if !i.GetVisible() {
+		return
+	}
+
+	i.scroll()
+
+	canvas := i.Canvas()
+	bounds := i.GetBounds()
+	style := i.GetStyleOf(i, base.RoleInput)
+
+	if !i.GetEnabled() {
+		style = i.GetStyleOf(i, base.RoleDisabled)
+	}
+
+	for y := 0; y < bounds.Height; y++ {
+		for x := 0; x < bounds.Width; x++ {
+			canvas.PrintCharWithBrush(x, y, ' ', style)
+		}
+	}
+
+	selectionStyle := i.GetStyleOf(i, base.RoleInputSelection)
+	start, end := i.GetSelection()
+	x := 0
+
+	for index := i.offset; index < len(i.text); index++ {
+		g := i.text[index]
+
+		if x+g.Width > bounds.Width {
+			break
+		}
+
+		st := style
+
+		if index >= start && index < end {
+			st = selectionStyle
+		}
+
+		canvas.PrintCellWithBrush(x, 0, g.Char, g.Combining, st)
+		x += g.Width
+	}
+
+	i.showCaret()
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (i *InputLine) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp, base.WmMouseDrag, base.WmPaste:
+		if i.GetOnReceiveMessage() != nil && i.GetOnReceiveMessage()(i, msg) {
+			return
+		}
+
+		i.manageInputLineMessage(msg)
+	case base.WmActivate:
+		i.selecting = false
+		// Caret is shown or hidden at next draw.
+		i.Invalidate()
+		fallthrough
+	default:
+		msg.Handler = i.Handler()
+		i.View.HandleMessage(msg)
+	}
+}
+
+func (i *InputLine) manageInputLineMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if i.GetOnDraw() != nil {
+			i.GetOnDraw()(i)
+		} else {
+			i.Draw()
+		}
+
+		return
+	}
+
+	if !i.GetEnabled() || !i.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		if i.GetFocused() {
+			i.manageKey(msg.Value.(*tcell.EventKey))
+		}
+	case base.WmLButtonDown:
+		ev := msg.Value.(*tcell.EventMouse)
+		x, _ := ev.Position()
+
+		i.selecting = true
+		i.moveCaret(i.positionAt(x), ev.Modifiers()&tcell.ModShift != 0)
+
+		if !i.GetFocused() {
+			base.SetFocus(i)
+		}
+	case base.WmMouseDrag:
+		if i.selecting {
+			x, _ := msg.Value.(*tcell.EventMouse).Position()
+
+			i.moveCaret(i.positionAt(x), true)
+		}
+	case base.WmLButtonUp:
+		i.selecting = false
+	case base.WmPaste:
+		i.Insert(msg.Value.(string))
+	}
+}
+
+func (i *InputLine) manageKey(ev *tcell.EventKey) {
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	ctrl := ev.Modifiers()&tcell.ModCtrl != 0
+
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		if ctrl {
+			i.moveCaret(i.wordLeft(i.caret), shift)
+		} else {
+			i.moveCaret(i.caret-1, shift)
+		}
+	case tcell.KeyRight:
+		if ctrl {
+			i.moveCaret(i.wordRight(i.caret), shift)
+		} else {
+			i.moveCaret(i.caret+1, shift)
+		}
+	case tcell.KeyHome:
+		i.moveCaret(0, shift)
+	case tcell.KeyEnd:
+		i.moveCaret(len(i.text), shift)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if start, end := i.GetSelection(); start != end {
+			i.DeleteSelection()
+		} else if i.caret > 0 {
+			i.replace(i.caret-1, i.caret, nil)
+		}
+	case tcell.KeyDelete:
+		if shift {
+			i.CutToClipboard()
+		} else if start, end := i.GetSelection(); start != end {
+			i.DeleteSelection()
+		} else if i.caret < len(i.text) {
+			i.replace(i.caret, i.caret+1, nil)
+		}
+	case tcell.KeyInsert:
+		switch {
+		case ctrl:
+			i.CopyToClipboard()
+		case shift:
+			i.PasteFromClipboard()
+		default:
+			i.overwrite = !i.overwrite
+		}
+	case tcell.KeyCtrlA:
+		i.SelectAll()
+	case tcell.KeyCtrlC:
+		i.CopyToClipboard()
+	case tcell.KeyCtrlX:
+		i.CutToClipboard()
+	case tcell.KeyCtrlV:
+		i.PasteFromClipboard()
+	case tcell.KeyEnter:
+		if i.OnSubmit != nil {
+			i.OnSubmit(i)
+		}
+	case tcell.KeyRune:
+		if ev.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) == 0 {
+			i.Insert(string(ev.Rune()))
+		}
+	}
+}
+
+// Replace characters from start to end (excluded) and put caret after new
+// characters.
+func (i *InputLine) replace(start, end int, graphemes []base.Grapheme) {
+	text := make([]base.Grapheme, 0, len(i.text)-(end-start)+len(graphemes))
+	text = append(text, i.text[:start]...)
+	text = append(text, graphemes...)
+	i.text = append(text, i.text[end:]...)
+
+	i.moveCaret(start+len(graphemes), false)
+	i.Invalidate()
+	i.change()
+}
+
+// Cut graphemes to not exceed MaxLength when `length` characters are kept.
+func (i *InputLine) limit(graphemes []base.Grapheme, length int) []base.Grapheme {
+	if i.MaxLength > 0 && length+len(graphemes) > i.MaxLength {
+		return graphemes[:base.MaxInt(i.MaxLength-length, 0)]
+	}
+
+	return graphemes
+}
+
+func (i *InputLine) change() {
+	if i.OnChange != nil {
+		i.OnChange(i)
+	}
+}
+
+// Move caret. If extend is false, selection is removed.
+func (i *InputLine) moveCaret(pos int, extend bool) {
+	pos = i.clamp(pos)
+
+	if pos == i.caret && (extend || i.anchor == i.caret) {
+		return
+	}
+
+	i.caret = pos
+
+	if !extend {
+		i.anchor = pos
+	}
+
+	i.scroll()
+	i.Invalidate()
+}
+
+func (i *InputLine) clamp(pos int) int {
+	return base.MaxInt(0, base.MinInt(pos, len(i.text)))
+}
+
+// Change first visible character to keep caret visible and view filled.
+func (i *InputLine) scroll() {
+	width := i.GetBounds().Width
+
+	if i.caret < i.offset {
+		i.offset = i.caret
+	}
+
+	// Caret needs one cell after text.
+	for i.offset < i.caret && i.textWidth(i.offset, i.caret)+1 > width {
+		i.offset++
+	}
+
+	for i.offset > 0 && i.textWidth(i.offset-1, len(i.text))+1 <= width {
+		i.offset--
+	}
+}
+
+// Width in cells of characters from start to end (excluded).
+func (i *InputLine) textWidth(start, end int) int {
+	width := 0
+
+	for _, g := range i.text[start:end] {
+		width += g.Width
+	}
+
+	return width
+}
+
+// Return character position at screen column x.
+func (i *InputLine) positionAt(x int) int {
+	x -= base.AbsoluteBounds(i).X
+
+	if x < 0 {
+		// Drag before view scrolls left.
+		return i.offset - 1
+	}
+
+	column := 0
+
+	for index := i.offset; index < len(i.text); index++ {
+		column += i.text[index].Width
+
+		if x < column {
+			return index
+		}
+	}
+
+	return len(i.text)
+}
+
+func (i *InputLine) wordLeft(pos int) int {
+	for pos > 0 && !isWordRune(i.text[pos-1].Char) {
+		pos--
+	}
+
+	for pos > 0 && isWordRune(i.text[pos-1].Char) {
+		pos--
+	}
+
+	return pos
+}
+
+func (i *InputLine) wordRight(pos int) int {
+	for pos < len(i.text) && isWordRune(i.text[pos].Char) {
+		pos++
+	}
+
+	for pos < len(i.text) && !isWordRune(i.text[pos].Char) {
+		pos++
+	}
+
+	return pos
+}
+
+// Caret is shown only if input line and its top-level view have focus.
+func (i *InputLine) showCaret() {
+	top, ok := base.TopLevel(i).(base.TView)
+
+	if i.GetFocused() && i.GetEnabled() && (!ok || top.GetFocused()) {
+		bounds := base.AbsoluteBounds(i)
+		x := bounds.X + i.textWidth(i.offset, i.caret)
+
+		i.GetMessageBus().Send(base.BuildShowCursorMessage(i.Handler(), x, bounds.Y))
+	} else {
+		i.GetMessageBus().Send(base.BuildHideCursorMessage(i.Handler()))
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Split text in graphemes until first line break.
+func splitLine(text string) []base.Grapheme {
+	if index := strings.IndexAny(text, "\r\n"); index >= 0 {
+		text = text[:index]
+	}
+
+	return base.SplitGraphemes(text)
+}
+
+func joinGraphemes(graphemes []base.Grapheme) string {
+	var b strings.Builder
+
+	for _, g := range graphemes {
+		b.WriteRune(g.Char)
+
+		for _, r := range g.Combining {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewInputLine create new empty input line in insert mode.
+func NewInputLine(name string, message base.Bus, parentCanvas base.TCanvas) InputLine {
+	i := InputLine{
+		View: base.NewView(name, message, parentCanvas),
+	}
+
+	i.SetEnabled(true)
+	i.SetVisible(true)
+
+	return i
+}
@@ -0,0 +1,256 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func createTestInputLine(appConfig base.ApplicationConfig, m *base.MemoryCanvas) *InputLine {
+	i := NewInputLine("input", appConfig.Message, m)
+	i.SetBounds(base.Rect{X: 0, Y: 0, Width: 5, Height: 1})
+	i.SetFocused(true)
+
+	return &i
+}
+
+// Return pending messages of type t.
+func pendingMessages(appConfig base.ApplicationConfig, t uint) []base.Message {
+	var messages []base.Message
+
+	for len(*appConfig.Message.Channel()) > 0 {
+		if msg := <-*appConfig.Message.Channel(); msg.Type == t {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages
+}
+
+func TestInputLine_edit(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	i := createTestInputLine(appConfig, base.NewMemoryCanvas(5, 1))
+	i.MaxLength = 6
+
+	changes := 0
+
+	i.OnChange = func(*InputLine) {
+		changes++
+	}
+
+	typeText(i, "hllo")
+	sendKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	sendKey(i, tcell.KeyRight, 0, tcell.ModNone)
+	typeText(i, "e")
+
+	if i.GetText() != "hello" || i.GetCaret() != 2 {
+		t.Errorf("Text must be 'hello' with caret at 2. Found '%s' %d", i.GetText(), i.GetCaret())
+	}
+
+	sendKey(i, tcell.KeyInsert, 0, tcell.ModNone)
+	typeText(i, "LLO!!")
+
+	if i.GetText() != "heLLO!" || !i.GetOverwrite() {
+		t.Errorf("Overwrite must be limited by MaxLength. Found '%s'", i.GetText())
+	}
+
+	sendKey(i, tcell.KeyBackspace2, 0, tcell.ModNone)
+	sendKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	sendKey(i, tcell.KeyDelete, 0, tcell.ModNone)
+
+	if i.GetText() != "eLLO" {
+		t.Errorf("Text must be 'eLLO'. Found '%s'", i.GetText())
+	}
+
+	// 5 typed, 4 overwritten, Backspace, Delete. Last typed is refused.
+	if changes != 11 {
+		t.Errorf("OnChange must be call 11 times. Found %d", changes)
+	}
+
+	submit := 0
+
+	i.OnSubmit = func(*InputLine) {
+		submit++
+	}
+
+	sendKey(i, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if submit != 1 {
+		t.Error("Enter must submit input line")
+	}
+
+	i.SetFocused(false)
+	typeText(i, "x")
+
+	if i.GetText() != "eLLO" {
+		t.Error("Input line without focus must ignore keys")
+	}
+}
+
+func TestInputLine_selection(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	i := createTestInputLine(appConfig, base.NewMemoryCanvas(5, 1))
+	i.SetText("foo bar_2, baz")
+
+	sendKey(i, tcell.KeyLeft, 0, tcell.ModCtrl)
+
+	if i.GetCaret() != 11 {
+		t.Errorf("Ctrl+Left must go to previous word. Found %d", i.GetCaret())
+	}
+
+	sendKey(i, tcell.KeyLeft, 0, tcell.ModCtrl|tcell.ModShift)
+
+	if i.GetSelectedText() != "bar_2, " {
+		t.Errorf("Ctrl+Shift+Left must select word. Found '%s'", i.GetSelectedText())
+	}
+
+	typeText(i, "x ")
+
+	if i.GetText() != "foo x baz" {
+		t.Errorf("Typing must replace selection. Found '%s'", i.GetText())
+	}
+
+	sendKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	sendKey(i, tcell.KeyRight, 0, tcell.ModCtrl)
+	sendKey(i, tcell.KeyEnd, 0, tcell.ModShift)
+
+	if start, end := i.GetSelection(); start != 4 || end != 9 {
+		t.Errorf("Selection must be from 4 to 9. Found %d, %d", start, end)
+	}
+
+	sendKey(i, tcell.KeyCtrlA, 0, tcell.ModCtrl)
+	sendKey(i, tcell.KeyBackspace, 0, tcell.ModNone)
+
+	if i.GetText() != "" {
+		t.Errorf("Text must be removed. Found '%s'", i.GetText())
+	}
+}
+
+func TestInputLine_Draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(5, 1)
+
+	i := createTestInputLine(appConfig, m)
+	i.SetText("abcdefgh")
+	pendingMessages(appConfig, base.WmNull)
+
+	i.HandleMessage(base.BuildDrawMessage(i.Handler()))
+
+	if l := memoryCanvasLine(m, 0); l != "efgh " {
+		t.Errorf("Input line must scroll to caret. Found '%s'", l)
+	}
+
+	if c := pendingMessages(appConfig, base.WmShowCursor); len(c) != 1 || c[0].Value.(base.Caret).X != 4 {
+		t.Errorf("Cursor must be shown at 4. Found %+v", c)
+	}
+
+	sendKey(i, tcell.KeyHome, 0, tcell.ModNone)
+	sendKey(i, tcell.KeyRight, 0, tcell.ModShift)
+	i.Draw()
+
+	if l := memoryCanvasLine(m, 0); l != "abcde" {
+		t.Errorf("Input line must scroll to start. Found '%s'", l)
+	}
+
+	if m.GetCell(0, 0).Style != i.GetStyle(base.RoleInputSelection) || m.GetCell(1, 0).Style != i.GetStyle(base.RoleInput) {
+		t.Error("Selection must use selection style")
+	}
+
+	// Text shorter than view is fully visible.
+	i.SetText("abc")
+	i.Draw()
+
+	if l := memoryCanvasLine(m, 0); l != "abc  " {
+		t.Errorf("Input line must show all text. Found '%s'", l)
+	}
+
+	i.SetFocused(false)
+	pendingMessages(appConfig, base.WmNull)
+	i.Draw()
+
+	if c := pendingMessages(appConfig, base.WmHideCursor); len(c) != 1 {
+		t.Error("Cursor must be hidden without focus")
+	}
+}
+
+func TestInputLine_clipboard(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	i := createTestInputLine(appConfig, base.NewMemoryCanvas(5, 1))
+	i.SetText("hello")
+	i.SetSelection(1, 3)
+	pendingMessages(appConfig, base.WmNull)
+
+	sendKey(i, tcell.KeyCtrlX, 0, tcell.ModCtrl)
+
+	if c := pendingMessages(appConfig, base.WmSetClipboard); len(c) != 1 || c[0].Value != "el" {
+		t.Errorf("Cut must put 'el' in clipboard. Found %+v", c)
+	}
+
+	if i.GetText() != "hlo" {
+		t.Errorf("Cut must remove selection. Found '%s'", i.GetText())
+	}
+
+	sendKey(i, tcell.KeyInsert, 0, tcell.ModShift)
+
+	if c := pendingMessages(appConfig, base.WmGetClipboard); len(c) != 1 || c[0].Value != i.Handler() {
+		t.Errorf("Paste must ask clipboard. Found %+v", c)
+	}
+
+	i.HandleMessage(base.BuildPasteMessage(i.Handler(), "EL\nsecond line"))
+
+	if i.GetText() != "hELlo" || i.GetCaret() != 3 {
+		t.Errorf("Paste must insert first line. Found '%s' %d", i.GetText(), i.GetCaret())
+	}
+}
+
+func TestInputLine_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	i := createTestInputLine(appConfig, base.NewMemoryCanvas(10, 1))
+	i.SetBounds(base.Rect{X: 2, Y: 0, Width: 5, Height: 1})
+	i.SetText("abcdefgh")
+	i.SetCaret(0)
+
+	mouse := func(t uint, x int, mod tcell.ModMask) {
+		i.HandleMessage(base.Message{
+			Handler: i.Handler(),
+			Type:    t,
+			Value:   tcell.NewEventMouse(x, 0, tcell.Button1, mod),
+		})
+	}
+
+	mouse(base.WmLButtonDown, 4, tcell.ModNone)
+	mouse(base.WmMouseDrag, 20, tcell.ModNone)
+	mouse(base.WmLButtonUp, 20, tcell.ModNone)
+
+	if start, end := i.GetSelection(); start != 2 || end != 8 {
+		t.Errorf("Drag must select from 2 to 8. Found %d, %d", start, end)
+	}
+
+	// Drag is ignored after button up. View is scrolled to show "efgh".
+	mouse(base.WmMouseDrag, 2, tcell.ModNone)
+	mouse(base.WmLButtonDown, 3, tcell.ModShift)
+
+	if i.GetSelectedText() != "cde" {
+		t.Errorf("Shift+click must extend selection. Found '%s'", i.GetSelectedText())
+	}
+}
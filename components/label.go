@@ -0,0 +1,347 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Alignment of text in view.
+type Alignment int
+
+const (
+	// AlignLeft text is on left side.
+	AlignLeft Alignment = iota
+	// AlignCenter text is centered.
+	AlignCenter
+	// AlignRight text is on right side.
+	AlignRight
+)
+
+// Label display static text. Caption can have a mnemonic (e.g. "&Name"):
+// Alt+letter give focus to FocusControl.
+type Label struct {
+	caption      string
+	mnemonic     mnemonic
+	alignment    Alignment
+	wordWrap     bool
+	autoSize     bool
+	focusControl base.TView
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (l *Label) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case l.Handler():
+		l.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		l.manageMyMessage(msg)
+
+		for _, child := range l.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range l.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetCaption change text. New lines are kept.
+func (l *Label) SetCaption(caption string) {
+	l.caption = caption
+	l.mnemonic = parseMnemonic(caption)
+	l.update()
+}
+
+// GetCaption return text with mnemonic mark.
+func (l *Label) GetCaption() string {
+	return l.caption
+}
+
+// SetAlignment change alignment of lines.
+func (l *Label) SetAlignment(a Alignment) {
+	l.alignment = a
+	l.update()
+}
+
+// GetAlignment return alignment of lines.
+func (l *Label) GetAlignment() Alignment {
+	return l.alignment
+}
+
+// SetWordWrap cut lines longer than width at spaces.
+func (l *Label) SetWordWrap(w bool) {
+	l.wordWrap = w
+	l.update()
+}
+
+// GetWordWrap return true if long lines are cut.
+func (l *Label) GetWordWrap() bool {
+	return l.wordWrap
+}
+
+// SetAutoSize resize label to fit text. With word wrap, only height change.
+func (l *Label) SetAutoSize(a bool) {
+	l.autoSize = a
+	l.update()
+}
+
+// GetAutoSize return true if label fit text.
+func (l *Label) GetAutoSize() bool {
+	return l.autoSize
+}
+
+// SetFocusControl set view focused by mnemonic.
+func (l *Label) SetFocusControl(v base.TView) {
+	l.focusControl = v
+}
+
+// GetFocusControl return view focused by mnemonic.
+func (l *Label) GetFocusControl() base.TView {
+	return l.focusControl
+}
+
+// Draw the label.
+func (l *Label) Draw() {
+	if !l.GetVisible() {
+		return
+	}
+
+	canvas := l.Canvas()
+
+	style := l.GetStyleOf(l, base.RoleLabel)
+
+	if !l.GetEnabled() {
+		style = l.GetStyleOf(l, base.RoleDisabled)
+	}
+
+	canvas.SetBrush(style)
+
+	bounds := l.GetBounds()
+	bounds.X = 0
+	bounds.Y = 0
+
+	canvas.Fill(bounds)
+
+	for y, line := range layoutText(l.mnemonic.text, bounds.Width, l.wordWrap) {
+		if y >= bounds.Height {
+			break
+		}
+
+		x := alignText(line.width, bounds.Width, l.alignment)
+
+		for i, g := range line.graphemes {
+			canvas.PrintCellWithBrush(x, y, g.Char, g.Combining, l.mnemonic.style(line.start+i, style))
+			x += g.Width
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (l *Label) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey:
+		if l.GetOnReceiveMessage() != nil && l.GetOnReceiveMessage()(l, msg) {
+			return
+		}
+
+		l.manageLabelMessage(msg)
+	default:
+		msg.Handler = l.Handler()
+		l.View.HandleMessage(msg)
+	}
+}
+
+func (l *Label) manageLabelMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if l.GetOnDraw() != nil {
+			l.GetOnDraw()(l)
+		} else {
+			l.Draw()
+		}
+	case base.WmKey:
+		ev := msg.Value.(*tcell.EventKey)
+
+		if l.focusControl != nil && l.GetVisible() && l.GetEnabled() && l.mnemonic.match(ev) {
+			base.SetFocus(l.focusControl)
+		}
+	}
+}
+
+// Resize if auto size and repaint.
+func (l *Label) update() {
+	if l.autoSize {
+		bounds := l.GetBounds()
+		width := bounds.Width
+
+		if !l.wordWrap {
+			width = 0
+
+			for _, line := range layoutText(l.mnemonic.text, 0, false) {
+				width = base.MaxInt(width, line.width)
+			}
+		}
+
+		r := bounds
+		r.Width = width
+		r.Height = len(layoutText(l.mnemonic.text, width, l.wordWrap))
+
+		if r != bounds {
+			l.Invalidate()
+			l.SetBounds(r)
+		}
+	}
+
+	l.Invalidate()
+}
+
+// Line of text. `start` is index of first grapheme in text (new lines are not
+// counted).
+type textLine struct {
+	graphemes []base.Grapheme
+	start     int
+	width     int
+}
+
+// Split text in lines. If wrap, lines are cut at spaces to fit `width`.
+func layoutText(text string, width int, wrap bool) []textLine {
+	var lines []textLine
+
+	start := 0
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		graphemes := base.SplitGraphemes(paragraph)
+
+		if !wrap || width <= 0 {
+			lines = append(lines, newTextLine(graphemes, start))
+		} else {
+			lines = append(lines, wrapGraphemes(graphemes, start, width)...)
+		}
+
+		start += len(graphemes)
+	}
+
+	return lines
+}
+
+func wrapGraphemes(graphemes []base.Grapheme, start int, width int) []textLine {
+	lines := []textLine{}
+	begin := 0
+
+	for {
+		end := begin
+		lineWidth := 0
+		lastSpace := -1
+
+		for end < len(graphemes) && lineWidth+graphemes[end].Width <= width {
+			if graphemes[end].Char == ' ' {
+				lastSpace = end
+			}
+
+			lineWidth += graphemes[end].Width
+			end++
+		}
+
+		if end < len(graphemes) {
+			if graphemes[end].Char == ' ' {
+				lastSpace = end
+			}
+
+			if lastSpace > begin {
+				// Cut at last space.
+				end = lastSpace
+			} else if end == begin {
+				// Character wider than line.
+				end++
+			}
+		}
+
+		lines = append(lines, newTextLine(graphemes[begin:end], start+begin))
+
+		// Spaces at cut are not displayed.
+		for end < len(graphemes) && graphemes[end].Char == ' ' {
+			end++
+		}
+
+		if end >= len(graphemes) {
+			return lines
+		}
+
+		begin = end
+	}
+}
+
+// Trailing spaces are not part of line width.
+func newTextLine(graphemes []base.Grapheme, start int) textLine {
+	line := textLine{
+		graphemes: graphemes,
+		start:     start,
+	}
+
+	last := len(graphemes)
+
+	for last > 0 && graphemes[last-1].Char == ' ' {
+		last--
+	}
+
+	for _, g := range graphemes[:last] {
+		line.width += g.Width
+	}
+
+	return line
+}
+
+// Return x of text in area.
+func alignText(textWidth int, width int, a Alignment) int {
+	switch a {
+	case AlignCenter:
+		return (width - textWidth) / 2
+	case AlignRight:
+		return width - textWidth
+	}
+
+	return 0
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewLabel create new label.
+func NewLabel(name string, message base.Bus, parentCanvas base.TCanvas) Label {
+	l := Label{
+		View:     base.NewView(name, message, parentCanvas),
+		mnemonic: parseMnemonic(""),
+	}
+
+	l.SetEnabled(true)
+	l.SetVisible(true)
+
+	return l
+}
@@ -0,0 +1,203 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func layoutLines(text string, width int, wrap bool) []string {
+	var result []string
+
+	for _, line := range layoutText(text, width, wrap) {
+		var b strings.Builder
+
+		for _, g := range line.graphemes {
+			b.WriteRune(g.Char)
+		}
+
+		result = append(result, strings.TrimRight(b.String(), " "))
+	}
+
+	return result
+}
+
+func TestLabel_parseMnemonic(t *testing.T) {
+	tests := []struct {
+		caption string
+		text    string
+		index   int
+		key     rune
+	}{
+		{"&File", "File", 0, 'f'},
+		{"Save &As", "Save As", 5, 'a'},
+		{"R&&D", "R&D", -1, 0},
+		{"日本&Go &x", "日本Go x", 2, 'g'},
+		{"End&", "End&", -1, 0},
+	}
+
+	for _, test := range tests {
+		m := parseMnemonic(test.caption)
+
+		if m.text != test.text || m.index != test.index || m.key != test.key {
+			t.Errorf("Mnemonic of '%s' must be %s/%d/%c. Found %+v", test.caption, test.text, test.index, test.key, m)
+		}
+	}
+}
+
+func TestLabel_layoutText(t *testing.T) {
+	tests := []struct {
+		text   string
+		width  int
+		wrap   bool
+		result []string
+	}{
+		{"Hello world", 5, false, []string{"Hello world"}},
+		{"Hello world", 5, true, []string{"Hello", "world"}},
+		{"Hello big world", 9, true, []string{"Hello big", "world"}},
+		{"Hello\n\nworld", 20, true, []string{"Hello", "", "world"}},
+		{"Unbreakable", 4, true, []string{"Unbr", "eaka", "ble"}},
+		{"日本語", 3, true, []string{"日", "本", "語"}},
+		{"a  b", 1, true, []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		if lines := layoutLines(test.text, test.width, test.wrap); strings.Join(lines, "|") != strings.Join(test.result, "|") {
+			t.Errorf("Layout of '%s' (%d) must be %q. Found %q", test.text, test.width, test.result, lines)
+		}
+	}
+
+	// Index of graphemes skip new lines.
+	if lines := layoutText("ab\ncd ef", 2, true); lines[1].start != 2 || lines[2].start != 5 {
+		t.Errorf("Wrong start of lines %+v", lines)
+	}
+}
+
+func TestLabel_Draw_alignment(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	tests := map[Alignment][]string{
+		AlignLeft:   {"Hello     ", "big world "},
+		AlignCenter: {"  Hello   ", "big world "},
+		AlignRight:  {"     Hello", " big world"},
+	}
+
+	for alignment, result := range tests {
+		m := base.NewMemoryCanvas(10, 2)
+
+		l := NewLabel("label", appConfig.Message, m)
+		l.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 2})
+		l.SetCaption("Hello\nbig world")
+		l.SetAlignment(alignment)
+
+		l.HandleMessage(base.BuildDrawMessage(l.Handler()))
+
+		for y, line := range result {
+			if found := memoryCanvasLine(m, y); found != line {
+				t.Errorf("Alignment %d, line %d must be '%s'. Found '%s'", alignment, y, line, found)
+			}
+		}
+	}
+}
+
+func TestLabel_Draw_mnemonic(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(6, 2)
+
+	l := NewLabel("label", appConfig.Message, m)
+	l.SetBounds(base.Rect{X: 0, Y: 0, Width: 6, Height: 2})
+	l.SetWordWrap(true)
+	l.SetCaption("Big &world")
+
+	l.Draw()
+
+	if line := memoryCanvasLine(m, 1); line != "world " {
+		t.Errorf("Text must be wrapped. Found '%s'", line)
+	}
+
+	for x := 0; x < 5; x++ {
+		if _, _, attr := m.GetCell(x, 1).Style.Decompose(); (attr&tcell.AttrUnderline != 0) != (x == 0) {
+			t.Errorf("Only mnemonic must be underlined (%d)", x)
+		}
+	}
+
+	if st := m.GetCell(1, 0).Style; st != l.GetStyle(base.RoleLabel) {
+		t.Error("Label must use label style")
+	}
+
+	l.SetEnabled(false)
+	l.Draw()
+
+	if st := m.GetCell(1, 0).Style; st != l.GetStyle(base.RoleDisabled) {
+		t.Error("Disabled label must use disabled style")
+	}
+}
+
+func TestLabel_SetAutoSize(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	l := NewLabel("label", appConfig.Message, base.NewMemoryCanvas(20, 20))
+	l.SetBounds(base.Rect{X: 1, Y: 2, Width: 4, Height: 1})
+	l.SetCaption("&Hello\nworld !")
+	l.SetAutoSize(true)
+
+	if r := l.GetBounds(); r != (base.Rect{X: 1, Y: 2, Width: 7, Height: 2}) {
+		t.Errorf("Label must fit text. Found %+v", r)
+	}
+
+	l.SetBounds(base.Rect{X: 1, Y: 2, Width: 5, Height: 1})
+	l.SetWordWrap(true)
+
+	if r := l.GetBounds(); r != (base.Rect{X: 1, Y: 2, Width: 5, Height: 3}) {
+		t.Errorf("Label with word wrap must keep width. Found %+v", r)
+	}
+}
+
+func TestLabel_FocusControl(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w := NewWindow("window", appConfig.Message, base.NewMemoryCanvas(20, 20))
+
+	l := NewLabel("label", appConfig.Message, w.ClientCanvas())
+	l.SetCaption("&Name")
+	l.SetParent(&w)
+	w.AddChild(&l)
+
+	control := base.NewView("control", appConfig.Message, w.ClientCanvas())
+	control.SetParent(&w)
+	w.AddChild(&control)
+
+	l.SetFocusControl(&control)
+
+	sendKey(&l, tcell.KeyRune, 'n', tcell.ModNone)
+	sendKey(&l, tcell.KeyRune, 'x', tcell.ModAlt)
+
+	if len(*appConfig.Message.Channel()) != 0 {
+		t.Fatal("Only Alt+mnemonic must give focus")
+	}
+
+	sendKey(&l, tcell.KeyRune, 'N', tcell.ModAlt)
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != base.WmActivate || msg.Handler != control.Handler() || msg.Value != base.WaActive {
+		t.Errorf("Control must be activated. Found %+v", msg)
+	}
+}
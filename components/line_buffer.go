@@ -0,0 +1,112 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Minimal size of gap when buffer grows.
+const lineBufferMinGap = 64
+
+// lineBuffer is a gap buffer of lines. Edits near the gap (where user types)
+// only move a few lines, so large texts stay fast.
+type lineBuffer struct {
+	lines    [][]rune
+	gapStart int
+	gapEnd   int
+}
+
+// Return number of lines.
+func (b *lineBuffer) Len() int {
+	return len(b.lines) - (b.gapEnd - b.gapStart)
+}
+
+// Return line i. Never modify returned slice.
+func (b *lineBuffer) Line(i int) []rune {
+	if i < b.gapStart {
+		return b.lines[i]
+	}
+
+	return b.lines[i+b.gapEnd-b.gapStart]
+}
+
+// Replace line i.
+func (b *lineBuffer) SetLine(i int, line []rune) {
+	if i < b.gapStart {
+		b.lines[i] = line
+	} else {
+		b.lines[i+b.gapEnd-b.gapStart] = line
+	}
+}
+
+// Insert lines before line i.
+func (b *lineBuffer) Insert(i int, lines [][]rune) {
+	b.moveGap(i)
+
+	if b.gapEnd-b.gapStart < len(lines) {
+		b.grow(len(lines))
+	}
+
+	copy(b.lines[b.gapStart:], lines)
+	b.gapStart += len(lines)
+}
+
+// Delete n lines from line i.
+func (b *lineBuffer) Delete(i, n int) {
+	b.moveGap(i)
+
+	// Let GC free deleted lines.
+	for j := b.gapEnd; j < b.gapEnd+n; j++ {
+		b.lines[j] = nil
+	}
+
+	b.gapEnd += n
+}
+
+// Replace all lines.
+func (b *lineBuffer) Reset(lines [][]rune) {
+	b.lines = lines
+	b.gapStart = len(lines)
+	b.gapEnd = len(lines)
+}
+
+// Move gap before line i.
+func (b *lineBuffer) moveGap(i int) {
+	switch {
+	case i < b.gapStart:
+		n := b.gapStart - i
+
+		copy(b.lines[b.gapEnd-n:b.gapEnd], b.lines[i:b.gapStart])
+		b.gapStart -= n
+		b.gapEnd -= n
+	case i > b.gapStart:
+		n := i - b.gapStart
+
+		copy(b.lines[b.gapStart:], b.lines[b.gapEnd:b.gapEnd+n])
+		b.gapStart += n
+		b.gapEnd += n
+	}
+}
+
+// Grow gap to have at least n free lines.
+func (b *lineBuffer) grow(n int) {
+	size := len(b.lines)*2 + n + lineBufferMinGap
+	lines := make([][]rune, size)
+
+	copy(lines, b.lines[:b.gapStart])
+
+	after := len(b.lines) - b.gapEnd
+	copy(lines[size-after:], b.lines[b.gapEnd:])
+
+	b.lines = lines
+	b.gapEnd = size - after
+}
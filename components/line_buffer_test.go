@@ -0,0 +1,61 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+)
+
+func lineBufferText(b *lineBuffer) []string {
+	lines := make([]string, b.Len())
+
+	for i := range lines {
+		lines[i] = string(b.Line(i))
+	}
+
+	return lines
+}
+
+func TestLineBuffer_edit(t *testing.T) {
+	b := lineBuffer{}
+	b.Reset([][]rune{[]rune("a"), []rune("d")})
+
+	b.Insert(1, [][]rune{[]rune("b"), []rune("c")})
+	b.Insert(4, [][]rune{[]rune("e")})
+	b.Delete(0, 1)
+	b.Insert(0, [][]rune{[]rune("A")})
+	b.SetLine(3, []rune("D"))
+
+	// Move gap far from edit.
+	for i := 0; i < 100; i++ {
+		b.Insert(b.Len(), [][]rune{[]rune("x")})
+		b.Delete(1, 0)
+	}
+
+	b.Delete(5, 100)
+
+	want := []string{"A", "b", "c", "D", "e"}
+	lines := lineBufferText(&b)
+
+	if len(lines) != len(want) {
+		t.Fatalf("Lines must be %v. Found %v", want, lines)
+	}
+
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Lines must be %v. Found %v", want, lines)
+		}
+	}
+}
@@ -0,0 +1,727 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// ListBoxColumnSeparator is draw between columns of list box.
+const ListBoxColumnSeparator = '│'
+
+// DefaultSearchDelay is time after last typed key before new search start.
+const DefaultSearchDelay = time.Second
+
+// ListDataSource give items of list box. Items are read only when they are
+// draw or searched.
+type ListDataSource interface {
+	// Number of items.
+	Count() int
+	// Text of item i.
+	Item(i int) string
+}
+
+// StringList is a ListDataSource for a slice of strings.
+type StringList []string
+
+// Count return number of items.
+func (l StringList) Count() int {
+	return len(l)
+}
+
+// Item return text of item i.
+func (l StringList) Item(i int) string {
+	return l[i]
+}
+
+// OnListBoxSelect is call when current item or selection of list box change.
+type OnListBoxSelect func(*ListBox)
+
+// OnListBoxActivate is call when an item is activated (Enter or double click).
+type OnListBoxActivate func(*ListBox, int)
+
+// ListBox show items of a ListDataSource. Items are laid out in columns, top
+// to bottom then left to right.
+//
+// Keys: arrows, PgUp, PgDn, Home, End, Enter (activate). Typing search item
+// starting with typed text, typed text is forgotten after SearchDelay. With
+// MultiSelect, Shift extends selection, Ctrl moves without changing
+// selection, Space toggles item and Ctrl+A selects all. Same modifiers work
+// with mouse click.
+type ListBox struct {
+	// Allow to select many items.
+	MultiSelect bool
+	// Number of columns (1 by default).
+	Columns int
+	// Number of items scrolled by mouse wheel.
+	WheelStep int
+	// Time after last typed key before new search start.
+	SearchDelay time.Duration
+	// Call when current item or selection change.
+	OnSelect OnListBoxSelect
+	// Call when item is activated.
+	OnActivate OnListBoxActivate
+
+	dataSource ListDataSource
+	current    int
+	anchor     int
+	top        int
+	selection  itemRanges
+	search     string
+	// Send WmTimer to forget typed text.
+	searchTimer *Timer
+	selecting   bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (l *ListBox) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case l.Handler():
+		l.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		l.manageMyMessage(msg)
+
+		for _, child := range l.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range l.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetDataSource change items. First item becomes current, selection is
+// cleared.
+func (l *ListBox) SetDataSource(ds ListDataSource) {
+	l.dataSource = ds
+	l.top = 0
+	l.current = -1
+	l.selection = nil
+	l.search = ""
+
+	if l.Count() > 0 {
+		l.moveTo(0, false, false)
+	}
+
+	l.Invalidate()
+}
+
+// GetDataSource return items.
+func (l *ListBox) GetDataSource() ListDataSource {
+	return l.dataSource
+}
+
+// Count return number of items.
+func (l *ListBox) Count() int {
+	if l.dataSource == nil {
+		return 0
+	}
+
+	return l.dataSource.Count()
+}
+
+// Refresh must be call when items of data source change. Current item and
+// selection are kept in new items.
+func (l *ListBox) Refresh() {
+	count := l.Count()
+
+	l.selection = l.selection.remove(count, int(^uint(0)>>1))
+	l.anchor = base.MinInt(l.anchor, count-1)
+	l.current = base.MinInt(base.MaxInt(l.current, 0), count-1)
+	l.scrollToCurrent()
+	l.Invalidate()
+}
+
+// SetItemIndex change current item. In single selection, current item is
+// selected item. Invalid index is ignored.
+func (l *ListBox) SetItemIndex(i int) {
+	if i >= 0 && i < l.Count() {
+		l.moveTo(i, false, false)
+	}
+}
+
+// GetItemIndex return current item, -1 if list is empty.
+func (l *ListBox) GetItemIndex() int {
+	return l.current
+}
+
+// SetTopIndex change first visible item.
+func (l *ListBox) SetTopIndex(i int) {
+	l.setTop(i)
+}
+
+// GetTopIndex return first visible item.
+func (l *ListBox) GetTopIndex() int {
+	return l.top
+}
+
+// IsSelected return true if item i is selected.
+func (l *ListBox) IsSelected(i int) bool {
+	if !l.MultiSelect {
+		return i == l.current && i >= 0
+	}
+
+	return l.selection.contains(i)
+}
+
+// SetSelected select or unselect item i. In single selection, it changes
+// current item.
+func (l *ListBox) SetSelected(i int, selected bool) {
+	if i < 0 || i >= l.Count() {
+		return
+	}
+
+	if !l.MultiSelect {
+		if selected {
+			l.SetItemIndex(i)
+		}
+
+		return
+	}
+
+	if selected {
+		l.selection = l.selection.add(i, i+1)
+	} else {
+		l.selection = l.selection.remove(i, i+1)
+	}
+
+	l.changed()
+}
+
+// GetSelected return indexes of selected items.
+func (l *ListBox) GetSelected() []int {
+	if !l.MultiSelect {
+		if l.current < 0 {
+			return []int{}
+		}
+
+		return []int{l.current}
+	}
+
+	selected := make([]int, 0, l.GetSelectedCount())
+
+	for _, r := range l.selection {
+		for i := r.start; i < r.end; i++ {
+			selected = append(selected, i)
+		}
+	}
+
+	return selected
+}
+
+// GetSelectedCount return number of selected items.
+func (l *ListBox) GetSelectedCount() int {
+	if !l.MultiSelect {
+		if l.current < 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	return l.selection.count()
+}
+
+// SelectAll select all items in multi selection.
+func (l *ListBox) SelectAll() {
+	if l.MultiSelect {
+		l.selection = itemRanges{{start: 0, end: l.Count()}}
+		l.changed()
+	}
+}
+
+// ClearSelection unselect all items in multi selection.
+func (l *ListBox) ClearSelection() {
+	if l.MultiSelect {
+		l.selection = nil
+		l.changed()
+	}
+}
+
+// Draw the list box.
+func (l *ListBox) Draw() {
+	if !l.GetVisible() {
+		return
+	}
+
+	canvas := l.Canvas()
+	bounds := l.GetBounds()
+	columns := l.columns()
+	style := l.GetStyleOf(l, base.RoleList)
+
+	if !l.GetEnabled() {
+		style = l.GetStyleOf(l, base.RoleDisabled)
+	}
+
+	for c := 0; c < columns; c++ {
+		x := c * bounds.Width / columns
+		width := (c+1)*bounds.Width/columns - x
+
+		if c < columns-1 {
+			// Separator in last cell of column.
+			width--
+
+			for y := 0; y < bounds.Height; y++ {
+				canvas.PrintCharWithBrush(x+width, y, ListBoxColumnSeparator, style)
+			}
+		}
+
+		for y := 0; y < bounds.Height; y++ {
+			l.drawItem(canvas, l.top+c*bounds.Height+y, x, y, width, style)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+func (l *ListBox) drawItem(canvas base.TCanvas, index, x, y, width int, style tcell.Style) {
+	text := ""
+
+	if index < l.Count() {
+		text = l.dataSource.Item(index)
+
+		switch {
+		case !l.GetEnabled():
+		case index == l.current && l.GetFocused():
+			style = l.GetStyleOf(l, base.RoleListFocused)
+		case l.IsSelected(index):
+			style = l.GetStyleOf(l, base.RoleSelection)
+		}
+	}
+
+	for i := 0; i < width; i++ {
+		canvas.PrintCharWithBrush(x+i, y, ' ', style)
+	}
+
+	// One space before text.
+	x++
+
+	for _, g := range base.SplitGraphemes(base.TruncateString(text, width-1)) {
+		canvas.PrintCellWithBrush(x, y, g.Char, g.Combining, style)
+		x += g.Width
+	}
+}
+
+// Manage message if it's for me.
+func (l *ListBox) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp, base.WmLButtonDblClick, base.WmMouseDrag,
+		base.WmMouseWheel, base.WmTimer:
+		if l.GetOnReceiveMessage() != nil && l.GetOnReceiveMessage()(l, msg) {
+			return
+		}
+
+		l.manageListBoxMessage(msg)
+	case base.WmActivate:
+		l.selecting = false
+		// Current item style depends on focus.
+		l.Invalidate()
+		fallthrough
+	default:
+		msg.Handler = l.Handler()
+		l.View.HandleMessage(msg)
+	}
+}
+
+func (l *ListBox) manageListBoxMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if l.GetOnDraw() != nil {
+			l.GetOnDraw()(l)
+		} else {
+			l.Draw()
+		}
+
+		return
+	case base.WmMouseWheel:
+		ev := msg.Value.(*tcell.EventMouse)
+
+		if !l.scrollWheel(ev.Buttons()) {
+			base.SendWheelToParent(l, ev)
+		}
+
+		return
+	case base.WmTimer:
+		l.searchTimer.SetEnabled(false)
+		l.search = ""
+
+		return
+	}
+
+	if !l.GetEnabled() || !l.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		if l.GetFocused() {
+			l.manageKey(msg.Value.(*tcell.EventKey))
+		}
+	case base.WmLButtonDown:
+		ev := msg.Value.(*tcell.EventMouse)
+		l.search = ""
+
+		if i := l.itemAt(ev.Position()); i >= 0 {
+			l.selecting = true
+
+			if l.MultiSelect && ev.Modifiers()&tcell.ModCtrl != 0 {
+				l.moveTo(i, false, true)
+				l.toggle(i)
+			} else {
+				l.moveTo(i, ev.Modifiers()&tcell.ModShift != 0, false)
+			}
+		}
+
+		if !l.GetFocused() {
+			base.SetFocus(l)
+		}
+	case base.WmMouseDrag:
+		if i := l.itemAt(msg.Value.(*tcell.EventMouse).Position()); i >= 0 && l.selecting {
+			l.moveTo(i, true, false)
+		}
+	case base.WmLButtonUp:
+		l.selecting = false
+	case base.WmLButtonDblClick:
+		if i := l.itemAt(msg.Value.(*tcell.EventMouse).Position()); i >= 0 {
+			l.activate(i)
+		}
+	}
+}
+
+func (l *ListBox) manageKey(ev *tcell.EventKey) {
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	ctrl := ev.Modifiers()&tcell.ModCtrl != 0
+	rows := base.MaxInt(l.GetBounds().Height, 1)
+	page := base.MaxInt(rows*l.columns()-1, 1)
+
+	if ev.Key() != tcell.KeyRune {
+		l.search = ""
+	}
+
+	switch ev.Key() {
+	case tcell.KeyUp:
+		l.moveTo(l.current-1, shift, ctrl)
+	case tcell.KeyDown:
+		l.moveTo(l.current+1, shift, ctrl)
+	case tcell.KeyLeft:
+		if l.columns() > 1 {
+			l.moveTo(l.current-rows, shift, ctrl)
+		}
+	case tcell.KeyRight:
+		if l.columns() > 1 {
+			l.moveTo(l.current+rows, shift, ctrl)
+		}
+	case tcell.KeyPgUp:
+		l.moveTo(l.current-page, shift, ctrl)
+	case tcell.KeyPgDn:
+		l.moveTo(l.current+page, shift, ctrl)
+	case tcell.KeyHome:
+		l.moveTo(0, shift, ctrl)
+	case tcell.KeyEnd:
+		l.moveTo(l.Count()-1, shift, ctrl)
+	case tcell.KeyEnter:
+		if l.current >= 0 {
+			l.activate(l.current)
+		}
+	case tcell.KeyCtrlA:
+		l.SelectAll()
+	case tcell.KeyRune:
+		if ev.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 {
+			return
+		}
+
+		if ev.Rune() == ' ' && l.MultiSelect && l.search == "" {
+			l.toggle(l.current)
+		} else {
+			l.searchItem(l.search + string(ev.Rune()))
+		}
+	}
+}
+
+// Move current item. With multi selection, extend selects items from anchor
+// to new current item, keep does not change selection.
+func (l *ListBox) moveTo(i int, extend, keep bool) {
+	if l.Count() == 0 {
+		return
+	}
+
+	i = base.MaxInt(0, base.MinInt(i, l.Count()-1))
+
+	if !l.MultiSelect {
+		extend, keep = false, false
+	}
+
+	current, selection := l.current, l.selection
+	l.current = i
+
+	switch {
+	case keep:
+		l.anchor = i
+	case extend:
+		l.selection = itemRanges{{start: base.MinInt(l.anchor, i), end: base.MaxInt(l.anchor, i) + 1}}
+	default:
+		l.anchor = i
+		l.selection = itemRanges{{start: i, end: i + 1}}
+	}
+
+	l.scrollToCurrent()
+
+	if current != l.current || !selection.equal(l.selection) {
+		l.changed()
+	}
+}
+
+func (l *ListBox) toggle(i int) {
+	if i >= 0 {
+		l.SetSelected(i, !l.IsSelected(i))
+	}
+}
+
+// Find first item starting with text (case insensitive) from current item.
+func (l *ListBox) searchItem(text string) {
+	count := l.Count()
+	prefix := strings.ToLower(text)
+
+	for n := 0; n < count; n++ {
+		i := (base.MaxInt(l.current, 0) + n) % count
+
+		if strings.HasPrefix(strings.ToLower(l.dataSource.Item(i)), prefix) {
+			l.search = text
+			l.moveTo(i, false, false)
+			l.restartSearchTimer()
+
+			return
+		}
+	}
+}
+
+// Typed text is forgotten after SearchDelay without key.
+func (l *ListBox) restartSearchTimer() {
+	l.searchTimer.SetEnabled(false)
+	l.searchTimer.SetIntervale(l.SearchDelay)
+	// List box can be moved after creation.
+	l.searchTimer.SetParent(l)
+	l.searchTimer.SetEnabled(true)
+}
+
+func (l *ListBox) activate(i int) {
+	if l.OnActivate != nil {
+		l.OnActivate(l, i)
+	}
+}
+
+func (l *ListBox) changed() {
+	l.Invalidate()
+
+	if l.OnSelect != nil {
+		l.OnSelect(l)
+	}
+}
+
+func (l *ListBox) columns() int {
+	return base.MaxInt(l.Columns, 1)
+}
+
+// Return item at screen coordinates, -1 if none.
+func (l *ListBox) itemAt(x, y int) int {
+	bounds := base.AbsoluteBounds(l)
+	x -= bounds.X
+	y -= bounds.Y
+
+	if x < 0 || y < 0 || x >= bounds.Width || y >= bounds.Height {
+		return -1
+	}
+
+	i := l.top + x*l.columns()/bounds.Width*bounds.Height + y
+
+	if i >= l.Count() {
+		return -1
+	}
+
+	return i
+}
+
+func (l *ListBox) setTop(i int) bool {
+	visible := base.MaxInt(l.GetBounds().Height, 1) * l.columns()
+	i = base.MaxInt(0, base.MinInt(i, l.Count()-visible))
+
+	if i == l.top {
+		return false
+	}
+
+	l.top = i
+	l.Invalidate()
+
+	return true
+}
+
+func (l *ListBox) scrollToCurrent() {
+	visible := base.MaxInt(l.GetBounds().Height, 1) * l.columns()
+
+	switch {
+	case l.current < l.top:
+		l.setTop(l.current)
+	case l.current >= l.top+visible:
+		l.setTop(l.current - visible + 1)
+	default:
+		// Items can be removed.
+		l.setTop(l.top)
+	}
+}
+
+// Return true if items move.
+func (l *ListBox) scrollWheel(buttons tcell.ButtonMask) bool {
+	switch {
+	case buttons&(tcell.WheelUp|tcell.WheelLeft) != 0:
+		return l.setTop(l.top - l.WheelStep)
+	case buttons&(tcell.WheelDown|tcell.WheelRight) != 0:
+		return l.setTop(l.top + l.WheelStep)
+	}
+
+	return false
+}
+
+//------------------------------------------------------------------------------
+// Selection.
+
+// Items from start to end (excluded).
+type itemRange struct {
+	start int
+	end   int
+}
+
+// Sorted and disjoint ranges of selected items. Selecting millions of items
+// need few memory.
+type itemRanges []itemRange
+
+func (s itemRanges) contains(i int) bool {
+	n := sort.Search(len(s), func(n int) bool {
+		return s[n].end > i
+	})
+
+	return n < len(s) && s[n].start <= i
+}
+
+func (s itemRanges) equal(o itemRanges) bool {
+	if len(s) != len(o) {
+		return false
+	}
+
+	for i := range s {
+		if s[i] != o[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s itemRanges) count() int {
+	count := 0
+
+	for _, r := range s {
+		count += r.end - r.start
+	}
+
+	return count
+}
+
+// Return ranges with items from start to end.
+func (s itemRanges) add(start, end int) itemRanges {
+	result := make(itemRanges, 0, len(s)+1)
+	added := itemRange{start: start, end: end}
+
+	for _, r := range s {
+		switch {
+		case r.end < added.start:
+			result = append(result, r)
+		case r.start > added.end:
+			if added.end >= 0 {
+				result = append(result, added)
+				added.end = -1
+			}
+
+			result = append(result, r)
+		default:
+			// Overlapping or adjacent ranges are merged.
+			added.start = base.MinInt(added.start, r.start)
+			added.end = base.MaxInt(added.end, r.end)
+		}
+	}
+
+	if added.end >= 0 {
+		result = append(result, added)
+	}
+
+	return result
+}
+
+// Return ranges without items from start to end.
+func (s itemRanges) remove(start, end int) itemRanges {
+	result := make(itemRanges, 0, len(s)+1)
+
+	for _, r := range s {
+		if r.end <= start || r.start >= end {
+			result = append(result, r)
+			continue
+		}
+
+		if r.start < start {
+			result = append(result, itemRange{start: r.start, end: start})
+		}
+
+		if r.end > end {
+			result = append(result, itemRange{start: end, end: r.end})
+		}
+	}
+
+	return result
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewListBox create new empty list box with one column.
+func NewListBox(name string, message base.Bus, parentCanvas base.TCanvas) ListBox {
+	timer := NewTimer(name+".search", DefaultSearchDelay, message)
+
+	l := ListBox{
+		Columns:     1,
+		WheelStep:   DefaultWheelStep,
+		SearchDelay: DefaultSearchDelay,
+		current:     -1,
+		searchTimer: &timer,
+		View:        base.NewView(name, message, parentCanvas),
+	}
+
+	l.SetEnabled(true)
+	l.SetVisible(true)
+
+	return l
+}
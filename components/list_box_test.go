@@ -0,0 +1,281 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Virtual data source that counts read items.
+type testListDataSource struct {
+	count int
+	reads int
+}
+
+func (d *testListDataSource) Count() int {
+	return d.count
+}
+
+func (d *testListDataSource) Item(i int) string {
+	d.reads++
+
+	return fmt.Sprintf("item %d", i)
+}
+
+func createTestListBox(appConfig base.ApplicationConfig, m *base.MemoryCanvas, ds ListDataSource) *ListBox {
+	l := NewListBox("list", appConfig.Message, m)
+	l.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 3})
+	l.SetDataSource(ds)
+	l.SetFocused(true)
+
+	return &l
+}
+
+func TestListBox_virtual_data_source(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 3)
+	ds := &testListDataSource{count: 10000000}
+
+	l := createTestListBox(appConfig, m, ds)
+
+	sendKey(l, tcell.KeyEnd, 0, tcell.ModNone)
+	sendKey(l, tcell.KeyPgUp, 0, tcell.ModNone)
+	l.HandleMessage(base.BuildDrawMessage(l.Handler()))
+
+	for y, line := range []string{" item 999…", " item 999…", " item 999…"} {
+		if s := memoryCanvasLine(m, y); s != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, line, s)
+		}
+	}
+
+	if l.GetItemIndex() != 9999997 || l.GetTopIndex() != 9999997 {
+		t.Errorf("Current and top item must be 9999997. Found %d, %d", l.GetItemIndex(), l.GetTopIndex())
+	}
+
+	if ds.reads != 3 {
+		t.Errorf("Only visible items must be read. Found %d", ds.reads)
+	}
+
+	if m.GetCell(0, 0).Style != l.GetStyle(base.RoleListFocused) || m.GetCell(0, 1).Style != l.GetStyle(base.RoleList) {
+		t.Error("Current item must use focused style")
+	}
+}
+
+func TestListBox_keys(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	l := createTestListBox(appConfig, base.NewMemoryCanvas(10, 3),
+		StringList{"apple", "banana", "blueberry", "cherry", "date", "fig"})
+
+	selects := 0
+	activated := -1
+
+	l.OnSelect = func(*ListBox) {
+		selects++
+	}
+	l.OnActivate = func(_ *ListBox, i int) {
+		activated = i
+	}
+
+	sendKey(l, tcell.KeyUp, 0, tcell.ModNone)
+	sendKey(l, tcell.KeyPgDn, 0, tcell.ModNone)
+
+	if l.GetItemIndex() != 2 || l.GetTopIndex() != 0 {
+		t.Errorf("PgDn must go to 2. Found %d", l.GetItemIndex())
+	}
+
+	sendKey(l, tcell.KeyDown, 0, tcell.ModNone)
+
+	if l.GetTopIndex() != 1 {
+		t.Errorf("List must scroll to current item. Found top %d", l.GetTopIndex())
+	}
+
+	// Search from current item.
+	for _, r := range "bl" {
+		sendKey(l, tcell.KeyRune, r, tcell.ModNone)
+	}
+
+	if l.GetItemIndex() != 2 {
+		t.Errorf("Search 'bl' must find blueberry. Found %d", l.GetItemIndex())
+	}
+
+	sendKey(l, tcell.KeyEnter, 0, tcell.ModNone)
+
+	if activated != 2 {
+		t.Errorf("Enter must activate blueberry. Found %d", activated)
+	}
+
+	// Up at first item does not change selection.
+	if selects != 4 {
+		t.Errorf("OnSelect must be call 4 times. Found %d", selects)
+	}
+
+	if s := l.GetSelected(); len(s) != 1 || s[0] != 2 {
+		t.Errorf("Current item must be selected. Found %v", s)
+	}
+}
+
+func TestListBox_search_delay(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	l := createTestListBox(appConfig, base.NewMemoryCanvas(10, 3),
+		StringList{"apple", "banana", "blueberry", "cherry"})
+	l.SearchDelay = 10 * time.Millisecond
+
+	typeText(l, "b")
+
+	if l.GetItemIndex() != 1 {
+		t.Errorf("Search 'b' must find banana. Found %d", l.GetItemIndex())
+	}
+
+	timeout := time.After(time.Second)
+
+	for reset := false; !reset; {
+		select {
+		case msg := <-*appConfig.Message.Channel():
+			if msg.Type == base.WmTimer && msg.Handler == l.Handler() {
+				l.HandleMessage(msg)
+
+				reset = true
+			}
+		case <-timeout:
+			t.Fatal("Timer message must be sent to list box")
+		}
+	}
+
+	// New search, not 'bc'.
+	typeText(l, "c")
+
+	if l.GetItemIndex() != 3 {
+		t.Errorf("Search 'c' must find cherry. Found %d", l.GetItemIndex())
+	}
+
+	// Stop timer.
+	l.HandleMessage(base.Message{Handler: l.Handler(), Type: base.WmTimer})
+}
+
+func TestListBox_multi_select(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	l := createTestListBox(appConfig, base.NewMemoryCanvas(10, 3), &testListDataSource{count: 1000000})
+	l.MultiSelect = true
+
+	sendKey(l, tcell.KeyDown, 0, tcell.ModShift)
+	sendKey(l, tcell.KeyDown, 0, tcell.ModShift)
+	sendKey(l, tcell.KeyDown, 0, tcell.ModCtrl)
+	sendKey(l, tcell.KeyDown, 0, tcell.ModCtrl)
+	sendKey(l, tcell.KeyRune, ' ', tcell.ModNone)
+
+	if s := l.GetSelected(); fmt.Sprint(s) != "[0 1 2 4]" {
+		t.Errorf("Selection must be [0 1 2 4]. Found %v", s)
+	}
+
+	l.HandleMessage(base.BuildDrawMessage(l.Handler()))
+
+	if l.IsSelected(3) || l.Canvas().GetCell(0, 0).Style != l.GetStyle(base.RoleSelection) {
+		t.Error("Selected items must use selection style")
+	}
+
+	sendKey(l, tcell.KeyCtrlA, 0, tcell.ModCtrl)
+	sendKey(l, tcell.KeyRune, ' ', tcell.ModNone)
+
+	if l.GetSelectedCount() != 999999 || l.IsSelected(4) {
+		t.Errorf("All items but 4 must be selected. Found %d", l.GetSelectedCount())
+	}
+
+	sendKey(l, tcell.KeyHome, 0, tcell.ModNone)
+
+	if l.GetSelectedCount() != 1 || !l.IsSelected(0) {
+		t.Error("Move without modifier must select only current item")
+	}
+}
+
+func TestListBox_mouse_and_columns(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 2)
+
+	l := createTestListBox(appConfig, m, StringList{"a", "b", "c", "d", "e"})
+	l.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 2})
+	l.Columns = 2
+	l.MultiSelect = true
+
+	activated := -1
+
+	l.OnActivate = func(_ *ListBox, i int) {
+		activated = i
+	}
+
+	l.HandleMessage(base.BuildDrawMessage(l.Handler()))
+
+	for y, line := range []string{" a  │ c   ", " b  │ d   "} {
+		if s := memoryCanvasLine(m, y); s != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, line, s)
+		}
+	}
+
+	click := func(side uint, x, y int, mod tcell.ModMask) {
+		l.HandleMessage(base.BuildClickMouseMessage(l.Handler(), tcell.NewEventMouse(x, y, tcell.Button1, mod), side))
+	}
+
+	click(base.WmLButtonDown, 6, 1, tcell.ModNone)
+	click(base.WmLButtonUp, 6, 1, tcell.ModNone)
+	click(base.WmLButtonDown, 1, 1, tcell.ModShift)
+	click(base.WmLButtonDown, 1, 0, tcell.ModCtrl)
+
+	if s := l.GetSelected(); fmt.Sprint(s) != "[0 1 2 3]" {
+		t.Errorf("Selection must be [0 1 2 3]. Found %v", s)
+	}
+
+	sendKey(l, tcell.KeyRight, 0, tcell.ModNone)
+
+	if l.GetItemIndex() != 2 || l.GetTopIndex() != 0 {
+		t.Errorf("Right must go to next column. Found %d", l.GetItemIndex())
+	}
+
+	sendKey(l, tcell.KeyRight, 0, tcell.ModNone)
+
+	if l.GetItemIndex() != 4 || l.GetTopIndex() != 1 {
+		t.Errorf("Right must scroll to last item. Found %d, top %d", l.GetItemIndex(), l.GetTopIndex())
+	}
+
+	click(base.WmLButtonDblClick, 7, 0, tcell.ModNone)
+
+	if activated != 3 {
+		t.Errorf("Double click must activate item 3. Found %d", activated)
+	}
+}
+
+func TestListBox_item_ranges(t *testing.T) {
+	var s itemRanges
+
+	s = s.add(5, 8).add(0, 2).add(10, 12).add(2, 3).add(7, 11)
+
+	if fmt.Sprint(s) != "[{0 3} {5 12}]" {
+		t.Errorf("Wrong ranges %v", s)
+	}
+
+	s = s.remove(1, 2).remove(6, 20)
+
+	if fmt.Sprint(s) != "[{0 1} {2 3} {5 6}]" || s.count() != 3 || !s.contains(5) || s.contains(1) {
+		t.Errorf("Wrong ranges %v", s)
+	}
+}
@@ -0,0 +1,1137 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// DefaultTabSize is number of columns between two tab stops.
+const DefaultTabSize = 8
+
+// OnMemoChange is call when text of memo change.
+type OnMemoChange func(*Memo)
+
+// TextPosition is a position in text. Column is index of character (rune) in
+// line.
+type TextPosition struct {
+	Line   int
+	Column int
+}
+
+// Memo is a multi-line text editor. Lines are stored in a gap buffer and only
+// visible lines are draw, so large texts stay fast.
+//
+// Keys: arrows, Home, End, PgUp, PgDn (Shift to select, Ctrl to jump words or
+// go to start and end of text), Enter, Tab, Backspace, Delete, Ctrl+A (select
+// all), Ctrl+Z (undo), Ctrl+Y (redo) and same clipboard keys than InputLine.
+type Memo struct {
+	// Number of columns between two tab stops.
+	TabSize int
+	// Number of lines or columns scrolled by mouse wheel.
+	WheelStep int
+	// Call when text change.
+	OnChange OnMemoChange
+
+	lines     lineBuffer
+	caret     TextPosition
+	anchor    TextPosition
+	wantedX   int
+	top       memoRow
+	left      int
+	wordWrap  bool
+	modified  bool
+	undo      []memoEdit
+	redo      []memoEdit
+	typing    bool
+	selecting bool
+
+	base.View
+}
+
+// A visual row: row of line when line is wrapped.
+type memoRow struct {
+	line int
+	row  int
+}
+
+// Undoable edit: text `removed` at start was replaced by `inserted`.
+type memoEdit struct {
+	start    TextPosition
+	removed  string
+	inserted string
+}
+
+// HandleMessage is use to manage message.
+func (m *Memo) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case m.Handler():
+		m.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		m.manageMyMessage(msg)
+
+		for _, child := range m.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range m.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetText replace text. Undo history is cleared and memo is not modified.
+func (m *Memo) SetText(text string) {
+	lines := strings.Split(text, "\n")
+	runes := make([][]rune, len(lines))
+
+	for i, line := range lines {
+		runes[i] = []rune(strings.TrimSuffix(line, "\r"))
+	}
+
+	m.reset(runes)
+}
+
+// GetText return text. Lines are separated by "\n".
+func (m *Memo) GetText() string {
+	return m.textRange(TextPosition{}, m.endOfText())
+}
+
+// Load replace text by content of reader. "\r\n" and "\n" are line breaks.
+// Text is not changed on error.
+func (m *Memo) Load(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	lines := [][]rune{}
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		eol := strings.HasSuffix(line, "\n")
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		lines = append(lines, []rune(line))
+
+		if !eol {
+			break
+		}
+	}
+
+	m.reset(lines)
+
+	return nil
+}
+
+// Save write text in writer. Memo is not modified after success.
+func (m *Memo) Save(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	for i := 0; i < m.lines.Len(); i++ {
+		if i > 0 {
+			writer.WriteByte('\n')
+		}
+
+		writer.WriteString(string(m.lines.Line(i)))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	m.modified = false
+
+	return nil
+}
+
+// SetModified change modified flag.
+func (m *Memo) SetModified(modified bool) {
+	m.modified = modified
+}
+
+// GetModified return true if text changed since last load or save.
+func (m *Memo) GetModified() bool {
+	return m.modified
+}
+
+// GetLineCount return number of lines. Empty memo has one line.
+func (m *Memo) GetLineCount() int {
+	return m.lines.Len()
+}
+
+// GetLine return text of line i.
+func (m *Memo) GetLine(i int) string {
+	return string(m.lines.Line(i))
+}
+
+// SetWordWrap enable soft wrap. Long lines are wrapped at view width and
+// there is no horizontal scroll.
+func (m *Memo) SetWordWrap(w bool) {
+	m.wordWrap = w
+	m.top = memoRow{line: m.top.line}
+	m.left = 0
+	m.scrollToCaret()
+	m.Invalidate()
+}
+
+// GetWordWrap return true if soft wrap is enabled.
+func (m *Memo) GetWordWrap() bool {
+	return m.wordWrap
+}
+
+// SetCaret move caret and remove selection.
+func (m *Memo) SetCaret(pos TextPosition) {
+	m.moveCaret(pos, false)
+}
+
+// GetCaret return caret position.
+func (m *Memo) GetCaret() TextPosition {
+	return m.caret
+}
+
+// SetSelection select text from start to end (excluded). Caret is moved at
+// end.
+func (m *Memo) SetSelection(start, end TextPosition) {
+	m.anchor = m.clamp(start)
+	m.moveCaret(end, true)
+}
+
+// GetSelection return start and end (excluded) of selection. Start equals
+// end if there is no selection.
+func (m *Memo) GetSelection() (TextPosition, TextPosition) {
+	if before(m.caret, m.anchor) {
+		return m.caret, m.anchor
+	}
+
+	return m.anchor, m.caret
+}
+
+// GetSelectedText return selected text.
+func (m *Memo) GetSelectedText() string {
+	return m.textRange(m.GetSelection())
+}
+
+// SelectAll select all text.
+func (m *Memo) SelectAll() {
+	m.SetSelection(TextPosition{}, m.endOfText())
+}
+
+// Insert text at caret like it is typed. Selection is replaced.
+func (m *Memo) Insert(text string) {
+	start, end := m.GetSelection()
+
+	if start != end || text != "" {
+		m.edit(start, end, strings.ReplaceAll(text, "\r\n", "\n"))
+	}
+}
+
+// DeleteSelection remove selected text.
+func (m *Memo) DeleteSelection() {
+	if start, end := m.GetSelection(); start != end {
+		m.edit(start, end, "")
+	}
+}
+
+// CanUndo return true if there is an edit to undo.
+func (m *Memo) CanUndo() bool {
+	return len(m.undo) > 0
+}
+
+// CanRedo return true if there is an undone edit to redo.
+func (m *Memo) CanRedo() bool {
+	return len(m.redo) > 0
+}
+
+// Undo last edit. Return false if there is nothing to undo.
+func (m *Memo) Undo() bool {
+	if !m.CanUndo() {
+		return false
+	}
+
+	e := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	m.redo = append(m.redo, e)
+
+	m.apply(e.start, endPosition(e.start, e.inserted), e.removed)
+
+	return true
+}
+
+// Redo last undone edit. Return false if there is nothing to redo.
+func (m *Memo) Redo() bool {
+	if !m.CanRedo() {
+		return false
+	}
+
+	e := m.redo[len(m.redo)-1]
+	m.redo = m.redo[:len(m.redo)-1]
+	m.undo = append(m.undo, e)
+
+	m.apply(e.start, endPosition(e.start, e.removed), e.inserted)
+
+	return true
+}
+
+// CopyToClipboard put selected text in application clipboard.
+func (m *Memo) CopyToClipboard() {
+	if start, end := m.GetSelection(); start != end {
+		m.GetMessageBus().Send(base.BuildSetClipboardMessage(m.GetSelectedText()))
+	}
+}
+
+// CutToClipboard put selected text in application clipboard and remove it.
+func (m *Memo) CutToClipboard() {
+	m.CopyToClipboard()
+	m.DeleteSelection()
+}
+
+// PasteFromClipboard ask clipboard to application. Text is inserted when
+// application answers.
+func (m *Memo) PasteFromClipboard() {
+	m.GetMessageBus().Send(base.BuildGetClipboardMessage(m.Handler()))
+}
+
+// ScrollBy scroll text by dy rows and dx columns. Return true if text moves.
+func (m *Memo) ScrollBy(dx, dy int) bool {
+	top, left := m.top, m.left
+
+	for ; dy > 0 && rowBefore(m.top, m.maxTop()); dy-- {
+		m.top, _ = m.nextRow(m.top)
+	}
+
+	for ; dy < 0; dy++ {
+		var ok bool
+
+		if m.top, ok = m.previousRow(m.top); !ok {
+			break
+		}
+	}
+
+	if !m.wordWrap {
+		m.left = base.MaxInt(0, base.MinInt(m.left+dx, m.maxLeft()))
+	}
+
+	if top == m.top && left == m.left {
+		return false
+	}
+
+	m.Invalidate()
+
+	return true
+}
+
+// GetTopLine return first visible line.
+func (m *Memo) GetTopLine() int {
+	return m.top.line
+}
+
+// GetLeftColumn return first visible column (in cells) when word wrap is
+// disabled.
+func (m *Memo) GetLeftColumn() int {
+	return m.left
+}
+
+// Draw the memo. Text cursor is shown when memo and its window have focus.
+func (m *Memo) Draw() {
+	if !m.GetVisible() {
+		return
+	}
+
+	m.clampTop()
+
+	canvas := m.Canvas()
+	bounds := m.GetBounds()
+	style := m.GetStyleOf(m, base.RoleInput)
+
+	if !m.GetEnabled() {
+		style = m.GetStyleOf(m, base.RoleDisabled)
+	}
+
+	for y := 0; y < bounds.Height; y++ {
+		for x := 0; x < bounds.Width; x++ {
+			canvas.PrintCharWithBrush(x, y, ' ', style)
+		}
+	}
+
+	selectionStyle := m.GetStyleOf(m, base.RoleInputSelection)
+	start, end := m.GetSelection()
+	r, ok := m.top, true
+
+	for y := 0; y < bounds.Height && ok; y++ {
+		line := m.lines.Line(r.line)
+		from, to := m.rowBounds(r)
+		x := -m.left
+
+		for i := from; i < to; {
+			width := m.runeWidth(line[i], x+m.left)
+			next := i + 1
+
+			for next < to && isCombining(line[next]) {
+				next++
+			}
+
+			st := style
+			pos := TextPosition{Line: r.line, Column: i}
+
+			if !before(pos, start) && before(pos, end) {
+				st = selectionStyle
+			}
+
+			if x >= 0 && x+width <= bounds.Width {
+				if line[i] == '\t' {
+					for j := 0; j < width; j++ {
+						canvas.PrintCharWithBrush(x+j, y, ' ', st)
+					}
+				} else {
+					canvas.PrintCellWithBrush(x, y, line[i], line[i+1:next], st)
+				}
+			}
+
+			x += width
+			i = next
+		}
+
+		r, ok = m.nextRow(r)
+	}
+
+	m.showCaret()
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (m *Memo) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp, base.WmMouseDrag, base.WmMouseWheel,
+		base.WmPaste:
+		if m.GetOnReceiveMessage() != nil && m.GetOnReceiveMessage()(m, msg) {
+			return
+		}
+
+		m.manageMemoMessage(msg)
+	case base.WmActivate:
+		m.selecting = false
+		m.Invalidate()
+		fallthrough
+	default:
+		msg.Handler = m.Handler()
+		m.View.HandleMessage(msg)
+	}
+}
+
+func (m *Memo) manageMemoMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if m.GetOnDraw() != nil {
+			m.GetOnDraw()(m)
+		} else {
+			m.Draw()
+		}
+
+		return
+	case base.WmMouseWheel:
+		ev := msg.Value.(*tcell.EventMouse)
+
+		if !m.scrollWheel(ev.Buttons()) {
+			base.SendWheelToParent(m, ev)
+		}
+
+		return
+	}
+
+	if !m.GetEnabled() || !m.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		if m.GetFocused() {
+			m.manageKey(msg.Value.(*tcell.EventKey))
+		}
+	case base.WmLButtonDown:
+		ev := msg.Value.(*tcell.EventMouse)
+
+		m.selecting = true
+		m.moveCaret(m.positionAt(ev.Position()), ev.Modifiers()&tcell.ModShift != 0)
+
+		if !m.GetFocused() {
+			base.SetFocus(m)
+		}
+	case base.WmMouseDrag:
+		if m.selecting {
+			m.moveCaret(m.positionAt(msg.Value.(*tcell.EventMouse).Position()), true)
+		}
+	case base.WmLButtonUp:
+		m.selecting = false
+	case base.WmPaste:
+		m.Insert(msg.Value.(string))
+	}
+}
+
+func (m *Memo) manageKey(ev *tcell.EventKey) {
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	ctrl := ev.Modifiers()&tcell.ModCtrl != 0
+
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		if ctrl {
+			m.moveCaret(m.wordLeft(m.caret), shift)
+		} else {
+			m.moveCaret(m.previousPosition(m.caret), shift)
+		}
+	case tcell.KeyRight:
+		if ctrl {
+			m.moveCaret(m.wordRight(m.caret), shift)
+		} else {
+			m.moveCaret(m.nextPosition(m.caret), shift)
+		}
+	case tcell.KeyUp:
+		m.moveRows(-1, shift)
+	case tcell.KeyDown:
+		m.moveRows(1, shift)
+	case tcell.KeyPgUp:
+		m.ScrollBy(0, 1-m.GetBounds().Height)
+		m.moveRows(1-m.GetBounds().Height, shift)
+	case tcell.KeyPgDn:
+		m.ScrollBy(0, m.GetBounds().Height-1)
+		m.moveRows(m.GetBounds().Height-1, shift)
+	case tcell.KeyHome:
+		if ctrl {
+			m.moveCaret(TextPosition{}, shift)
+		} else {
+			m.moveCaret(TextPosition{Line: m.caret.Line}, shift)
+		}
+	case tcell.KeyEnd:
+		if ctrl {
+			m.moveCaret(m.endOfText(), shift)
+		} else {
+			m.moveCaret(TextPosition{Line: m.caret.Line, Column: len(m.lines.Line(m.caret.Line))}, shift)
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if start, end := m.GetSelection(); start != end {
+			m.DeleteSelection()
+		} else if m.caret != (TextPosition{}) {
+			m.edit(m.previousPosition(m.caret), m.caret, "")
+		}
+	case tcell.KeyDelete:
+		if shift {
+			m.CutToClipboard()
+		} else if start, end := m.GetSelection(); start != end {
+			m.DeleteSelection()
+		} else if m.caret != m.endOfText() {
+			m.edit(m.caret, m.nextPosition(m.caret), "")
+		}
+	case tcell.KeyInsert:
+		if ctrl {
+			m.CopyToClipboard()
+		} else if shift {
+			m.PasteFromClipboard()
+		}
+	case tcell.KeyCtrlA:
+		m.SelectAll()
+	case tcell.KeyCtrlC:
+		m.CopyToClipboard()
+	case tcell.KeyCtrlX:
+		m.CutToClipboard()
+	case tcell.KeyCtrlV:
+		m.PasteFromClipboard()
+	case tcell.KeyCtrlZ:
+		m.Undo()
+	case tcell.KeyCtrlY:
+		m.Redo()
+	case tcell.KeyEnter:
+		m.Insert("\n")
+	case tcell.KeyTab:
+		m.typeText("\t")
+	case tcell.KeyRune:
+		if ev.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) == 0 {
+			m.typeText(string(ev.Rune()))
+		}
+	}
+}
+
+// Insert typed text. Following typed characters are undone together.
+func (m *Memo) typeText(text string) {
+	start, end := m.GetSelection()
+	last := len(m.undo) - 1
+
+	if m.typing && start == end && last >= 0 && len(m.redo) == 0 &&
+		endPosition(m.undo[last].start, m.undo[last].inserted) == m.caret {
+		m.undo[last].inserted += text
+		m.apply(m.caret, m.caret, text)
+	} else {
+		m.edit(start, end, text)
+	}
+
+	m.typing = true
+}
+
+// Replace text from start to end and record it in undo history.
+func (m *Memo) edit(start, end TextPosition, text string) {
+	m.undo = append(m.undo, memoEdit{
+		start:    start,
+		removed:  m.textRange(start, end),
+		inserted: text,
+	})
+	m.redo = nil
+
+	m.apply(start, end, text)
+}
+
+// Replace text from start to end and put caret after new text.
+func (m *Memo) apply(start, end TextPosition, text string) {
+	first := m.lines.Line(start.Line)
+	last := m.lines.Line(end.Line)
+
+	prefix := first[:start.Column]
+	suffix := last[end.Column:]
+
+	parts := strings.Split(text, "\n")
+	lines := make([][]rune, len(parts))
+
+	for i, part := range parts {
+		lines[i] = []rune(part)
+	}
+
+	lines[0] = append(append([]rune{}, prefix...), lines[0]...)
+	lines[len(lines)-1] = append(lines[len(lines)-1], suffix...)
+
+	m.lines.Delete(start.Line, end.Line-start.Line+1)
+	m.lines.Insert(start.Line, lines)
+
+	m.modified = true
+	m.moveCaret(endPosition(start, text), false)
+	m.Invalidate()
+
+	if m.OnChange != nil {
+		m.OnChange(m)
+	}
+}
+
+// Replace all lines, clear history.
+func (m *Memo) reset(lines [][]rune) {
+	if len(lines) == 0 {
+		lines = [][]rune{{}}
+	}
+
+	m.lines.Reset(lines)
+	m.undo = nil
+	m.redo = nil
+	m.modified = false
+	m.top = memoRow{}
+	m.left = 0
+	m.caret = TextPosition{}
+	m.anchor = m.caret
+	m.wantedX = 0
+	m.typing = false
+	m.Invalidate()
+}
+
+// Return text from start to end (excluded).
+func (m *Memo) textRange(start, end TextPosition) string {
+	if start.Line == end.Line {
+		return string(m.lines.Line(start.Line)[start.Column:end.Column])
+	}
+
+	var b strings.Builder
+
+	b.WriteString(string(m.lines.Line(start.Line)[start.Column:]))
+
+	for i := start.Line + 1; i < end.Line; i++ {
+		b.WriteByte('\n')
+		b.WriteString(string(m.lines.Line(i)))
+	}
+
+	b.WriteByte('\n')
+	b.WriteString(string(m.lines.Line(end.Line)[:end.Column]))
+
+	return b.String()
+}
+
+func (m *Memo) endOfText() TextPosition {
+	line := m.lines.Len() - 1
+
+	return TextPosition{Line: line, Column: len(m.lines.Line(line))}
+}
+
+func (m *Memo) clamp(pos TextPosition) TextPosition {
+	pos.Line = base.MaxInt(0, base.MinInt(pos.Line, m.lines.Len()-1))
+	pos.Column = base.MaxInt(0, base.MinInt(pos.Column, len(m.lines.Line(pos.Line))))
+
+	return pos
+}
+
+// Move caret. If extend is false, selection is removed.
+func (m *Memo) moveCaret(pos TextPosition, extend bool) {
+	m.setCaret(pos, extend)
+	m.wantedX = m.caretX()
+}
+
+// Move caret without changing wanted column of vertical moves.
+func (m *Memo) setCaret(pos TextPosition, extend bool) {
+	m.caret = m.clamp(pos)
+	m.typing = false
+
+	if !extend {
+		m.anchor = m.caret
+	}
+
+	m.scrollToCaret()
+	m.Invalidate()
+}
+
+// Move caret up (n < 0) or down by n rows, keeping column.
+func (m *Memo) moveRows(n int, extend bool) {
+	r, ok := m.rowOf(m.caret), true
+
+	for i := 0; i < n && ok; i++ {
+		r, ok = m.nextRow(r)
+	}
+
+	for i := 0; i > n && ok; i-- {
+		r, ok = m.previousRow(r)
+	}
+
+	switch {
+	case ok:
+		m.setCaret(m.positionInRow(r, m.wantedX), extend)
+	case n > 0:
+		// Already on last row.
+		m.moveCaret(m.endOfText(), extend)
+	default:
+		m.moveCaret(TextPosition{}, extend)
+	}
+}
+
+func (m *Memo) previousPosition(pos TextPosition) TextPosition {
+	if pos.Column == 0 {
+		if pos.Line == 0 {
+			return pos
+		}
+
+		return TextPosition{Line: pos.Line - 1, Column: len(m.lines.Line(pos.Line - 1))}
+	}
+
+	line := m.lines.Line(pos.Line)
+	pos.Column--
+
+	for pos.Column > 0 && isCombining(line[pos.Column]) {
+		pos.Column--
+	}
+
+	return pos
+}
+
+func (m *Memo) nextPosition(pos TextPosition) TextPosition {
+	line := m.lines.Line(pos.Line)
+
+	if pos.Column >= len(line) {
+		if pos.Line+1 >= m.lines.Len() {
+			return pos
+		}
+
+		return TextPosition{Line: pos.Line + 1}
+	}
+
+	pos.Column++
+
+	for pos.Column < len(line) && isCombining(line[pos.Column]) {
+		pos.Column++
+	}
+
+	return pos
+}
+
+func (m *Memo) wordLeft(pos TextPosition) TextPosition {
+	if pos.Column == 0 {
+		return m.previousPosition(pos)
+	}
+
+	line := m.lines.Line(pos.Line)
+
+	for pos.Column > 0 && !isWordRune(line[pos.Column-1]) {
+		pos.Column--
+	}
+
+	for pos.Column > 0 && isWordRune(line[pos.Column-1]) {
+		pos.Column--
+	}
+
+	return pos
+}
+
+func (m *Memo) wordRight(pos TextPosition) TextPosition {
+	line := m.lines.Line(pos.Line)
+
+	if pos.Column >= len(line) {
+		return m.nextPosition(pos)
+	}
+
+	for pos.Column < len(line) && isWordRune(line[pos.Column]) {
+		pos.Column++
+	}
+
+	for pos.Column < len(line) && !isWordRune(line[pos.Column]) {
+		pos.Column++
+	}
+
+	return pos
+}
+
+// Width in cells of rune at column x (tab goes to next tab stop).
+func (m *Memo) runeWidth(r rune, x int) int {
+	if r == '\t' {
+		tabSize := base.MaxInt(m.TabSize, 1)
+
+		return tabSize - x%tabSize
+	}
+
+	return base.MaxInt(runewidth.RuneWidth(r), 1)
+}
+
+// Return start of each row of line. Without word wrap, line has one row.
+func (m *Memo) rowStarts(index int) []int {
+	width := m.GetBounds().Width
+	starts := []int{0}
+
+	if !m.wordWrap || width <= 0 {
+		return starts
+	}
+
+	line := m.lines.Line(index)
+	start, x, space := 0, 0, -1
+
+	for i := 0; i < len(line); i++ {
+		if isCombining(line[i]) {
+			continue
+		}
+
+		w := m.runeWidth(line[i], x)
+
+		// Spaces can overflow, row is broken after them.
+		if x+w > width && i > start && line[i] != ' ' {
+			// Break after last space of row if any.
+			if space > start {
+				i = space
+			}
+
+			starts = append(starts, i)
+			start, x, space = i, 0, -1
+			w = m.runeWidth(line[i], x)
+		}
+
+		if line[i] == ' ' {
+			space = i + 1
+		}
+
+		x += w
+	}
+
+	return starts
+}
+
+// Return first and last (excluded) column of row.
+func (m *Memo) rowBounds(r memoRow) (int, int) {
+	starts := m.rowStarts(r.line)
+
+	if r.row+1 < len(starts) {
+		return starts[r.row], starts[r.row+1]
+	}
+
+	return starts[len(starts)-1], len(m.lines.Line(r.line))
+}
+
+// Return row containing position.
+func (m *Memo) rowOf(pos TextPosition) memoRow {
+	starts := m.rowStarts(pos.Line)
+	row := len(starts) - 1
+
+	for row > 0 && starts[row] > pos.Column {
+		row--
+	}
+
+	return memoRow{line: pos.Line, row: row}
+}
+
+func (m *Memo) nextRow(r memoRow) (memoRow, bool) {
+	if r.row+1 < len(m.rowStarts(r.line)) {
+		return memoRow{line: r.line, row: r.row + 1}, true
+	}
+
+	if r.line+1 < m.lines.Len() {
+		return memoRow{line: r.line + 1}, true
+	}
+
+	return r, false
+}
+
+func (m *Memo) previousRow(r memoRow) (memoRow, bool) {
+	if r.row > 0 {
+		return memoRow{line: r.line, row: r.row - 1}, true
+	}
+
+	if r.line > 0 {
+		return memoRow{line: r.line - 1, row: len(m.rowStarts(r.line-1)) - 1}, true
+	}
+
+	return r, false
+}
+
+// Return x (in cells) of column in its row.
+func (m *Memo) columnX(pos TextPosition) int {
+	line := m.lines.Line(pos.Line)
+	from, _ := m.rowBounds(m.rowOf(pos))
+	x := 0
+
+	for i := from; i < pos.Column; i++ {
+		if !isCombining(line[i]) {
+			x += m.runeWidth(line[i], x)
+		}
+	}
+
+	return x
+}
+
+func (m *Memo) caretX() int {
+	return m.columnX(m.caret)
+}
+
+// Return position in row at x (in cells).
+func (m *Memo) positionInRow(r memoRow, x int) TextPosition {
+	line := m.lines.Line(r.line)
+	from, to := m.rowBounds(r)
+	column := 0
+
+	for i := from; i < to; i++ {
+		if isCombining(line[i]) {
+			continue
+		}
+
+		column += m.runeWidth(line[i], column)
+
+		if x < column {
+			return TextPosition{Line: r.line, Column: i}
+		}
+	}
+
+	// End of wrapped row is start of next row.
+	if to < len(line) && to > from {
+		to--
+	}
+
+	return TextPosition{Line: r.line, Column: to}
+}
+
+// Return text position at screen coordinates.
+func (m *Memo) positionAt(x, y int) TextPosition {
+	bounds := base.AbsoluteBounds(m)
+	x -= bounds.X
+	y -= bounds.Y
+
+	r, ok := m.top, true
+
+	for ; y > 0 && ok; y-- {
+		r, ok = m.nextRow(r)
+	}
+
+	for ; y < 0 && ok; y++ {
+		// Drag above view scrolls up.
+		r, ok = m.previousRow(r)
+	}
+
+	return m.positionInRow(r, x+m.left)
+}
+
+// Last top row: last row of text is at bottom of view.
+func (m *Memo) maxTop() memoRow {
+	last := m.lines.Len() - 1
+	r := memoRow{line: last, row: len(m.rowStarts(last)) - 1}
+
+	for y := 1; y < m.GetBounds().Height; y++ {
+		var ok bool
+
+		if r, ok = m.previousRow(r); !ok {
+			break
+		}
+	}
+
+	return r
+}
+
+// Last left column: end of longest visible line is at right of view.
+func (m *Memo) maxLeft() int {
+	width := 0
+	r, ok := m.top, true
+
+	for y := 0; y < m.GetBounds().Height && ok; y++ {
+		line := m.lines.Line(r.line)
+		width = base.MaxInt(width, m.columnX(TextPosition{Line: r.line, Column: len(line)}))
+		r, ok = m.nextRow(r)
+	}
+
+	return base.MaxInt(0, width+1-m.GetBounds().Width)
+}
+
+// Top row can be out of text after edit or resize.
+func (m *Memo) clampTop() {
+	if m.top.line >= m.lines.Len() {
+		m.top = memoRow{line: m.lines.Len() - 1}
+	}
+
+	m.top.row = base.MinInt(m.top.row, len(m.rowStarts(m.top.line))-1)
+}
+
+// Scroll to keep caret visible.
+func (m *Memo) scrollToCaret() {
+	m.clampTop()
+
+	r := m.rowOf(m.caret)
+
+	if rowBefore(r, m.top) {
+		m.top = r
+	} else {
+		// Caret must be in height rows from top.
+		first := r
+
+		for y := 1; y < m.GetBounds().Height; y++ {
+			var ok bool
+
+			if first, ok = m.previousRow(first); !ok {
+				break
+			}
+		}
+
+		if rowBefore(m.top, first) {
+			m.top = first
+		}
+	}
+
+	if m.wordWrap {
+		m.left = 0
+		return
+	}
+
+	x := m.caretX()
+	width := m.GetBounds().Width
+
+	if x < m.left {
+		m.left = x
+	} else if x >= m.left+width {
+		m.left = x - width + 1
+	}
+}
+
+// Return true if content move.
+func (m *Memo) scrollWheel(buttons tcell.ButtonMask) bool {
+	dx, dy := 0, 0
+
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		dy = -m.WheelStep
+	case buttons&tcell.WheelDown != 0:
+		dy = m.WheelStep
+	case buttons&tcell.WheelLeft != 0:
+		dx = -m.WheelStep
+	case buttons&tcell.WheelRight != 0:
+		dx = m.WheelStep
+	}
+
+	return m.ScrollBy(dx, dy)
+}
+
+// Caret is shown only if memo, its top-level view have focus and caret is
+// visible.
+func (m *Memo) showCaret() {
+	top, ok := base.TopLevel(m).(base.TView)
+
+	if m.GetFocused() && m.GetEnabled() && (!ok || top.GetFocused()) {
+		bounds := base.AbsoluteBounds(m)
+		caret := m.rowOf(m.caret)
+		y := 0
+
+		for r := m.top; y < bounds.Height && rowBefore(r, caret); y++ {
+			r, _ = m.nextRow(r)
+		}
+
+		x := m.caretX() - m.left
+
+		if !rowBefore(caret, m.top) && x >= 0 && x < bounds.Width && y < bounds.Height {
+			m.GetMessageBus().Send(base.BuildShowCursorMessage(m.Handler(), bounds.X+x, bounds.Y+y))
+			return
+		}
+	}
+
+	m.GetMessageBus().Send(base.BuildHideCursorMessage(m.Handler()))
+}
+
+// Return position after text inserted at start.
+func endPosition(start TextPosition, text string) TextPosition {
+	lines := strings.Split(text, "\n")
+	last := len([]rune(lines[len(lines)-1]))
+
+	if len(lines) == 1 {
+		return TextPosition{Line: start.Line, Column: start.Column + last}
+	}
+
+	return TextPosition{Line: start.Line + len(lines) - 1, Column: last}
+}
+
+// Return true if position a is before b.
+func before(a, b TextPosition) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
+
+// Return true if row a is above b.
+func rowBefore(a, b memoRow) bool {
+	return a.line < b.line || (a.line == b.line && a.row < b.row)
+}
+
+// Combining character is draw with previous character.
+func isCombining(r rune) bool {
+	return r != '\t' && runewidth.RuneWidth(r) == 0 && !unicode.IsControl(r)
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewMemo create new empty memo.
+func NewMemo(name string, message base.Bus, parentCanvas base.TCanvas) Memo {
+	m := Memo{
+		TabSize:   DefaultTabSize,
+		WheelStep: DefaultWheelStep,
+		View:      base.NewView(name, message, parentCanvas),
+	}
+
+	m.lines.Reset([][]rune{{}})
+	m.SetEnabled(true)
+	m.SetVisible(true)
+
+	return m
+}
@@ -0,0 +1,295 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func createTestMemo(appConfig base.ApplicationConfig, m *base.MemoryCanvas) *Memo {
+	memo := NewMemo("memo", appConfig.Message, m)
+	memo.SetBounds(base.Rect{X: 0, Y: 0, Width: 6, Height: 3})
+	memo.SetFocused(true)
+
+	return &memo
+}
+
+func TestMemo_Load_Save(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := createTestMemo(appConfig, base.NewMemoryCanvas(6, 3))
+
+	if e := m.Load(strings.NewReader("one\r\ntwo\n\nfour\n")); e != nil {
+		t.Fatal(e)
+	}
+
+	if m.GetLineCount() != 5 || m.GetLine(1) != "two" || m.GetModified() {
+		t.Errorf("Wrong loaded text: %q", m.GetText())
+	}
+
+	typeText(m, "1")
+
+	if !m.GetModified() || m.GetLine(0) != "1one" {
+		t.Error("Memo must be modified")
+	}
+
+	var b bytes.Buffer
+
+	if e := m.Save(&b); e != nil {
+		t.Fatal(e)
+	}
+
+	if b.String() != "1one\ntwo\n\nfour\n" || m.GetModified() {
+		t.Errorf("Wrong saved text: %q", b.String())
+	}
+}
+
+func TestMemo_edit_undo_redo(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := createTestMemo(appConfig, base.NewMemoryCanvas(6, 3))
+
+	changes := 0
+
+	m.OnChange = func(*Memo) {
+		changes++
+	}
+
+	typeText(m, "hello\nworld")
+	sendKey(m, tcell.KeyHome, 0, tcell.ModNone)
+	sendKey(m, tcell.KeyBackspace2, 0, tcell.ModNone)
+	sendKey(m, tcell.KeyTab, 0, tcell.ModNone)
+
+	if m.GetText() != "hello\tworld" || m.GetCaret() != (TextPosition{Line: 0, Column: 6}) {
+		t.Errorf("Wrong text %q, caret %+v", m.GetText(), m.GetCaret())
+	}
+
+	if changes != 13 {
+		t.Errorf("OnChange must be call 13 times. Found %d", changes)
+	}
+
+	// Tab, Backspace, "world", Enter, "hello".
+	steps := []string{"helloworld", "hello\nworld", "hello\n", "hello", ""}
+
+	for _, step := range steps {
+		if !m.Undo() || m.GetText() != step {
+			t.Errorf("Undo must give %q. Found %q", step, m.GetText())
+		}
+	}
+
+	if m.Undo() {
+		t.Error("Nothing to undo")
+	}
+
+	sendKey(m, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+	sendKey(m, tcell.KeyCtrlY, 0, tcell.ModCtrl)
+
+	if m.GetText() != "hello\n" || !m.CanRedo() {
+		t.Errorf("Redo must give \"hello\\n\". Found %q", m.GetText())
+	}
+
+	// New edit clears redo.
+	typeText(m, "x")
+
+	if m.CanRedo() || m.GetText() != "hello\nx" {
+		t.Errorf("Edit must clear redo. Found %q", m.GetText())
+	}
+
+	sendKey(m, tcell.KeyCtrlZ, 0, tcell.ModCtrl)
+
+	if m.GetText() != "hello\n" {
+		t.Errorf("Ctrl+Z must undo. Found %q", m.GetText())
+	}
+}
+
+func TestMemo_navigation_selection(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := createTestMemo(appConfig, base.NewMemoryCanvas(6, 3))
+	m.SetText("first line\nab\nthird line")
+	m.SetCaret(TextPosition{Line: 0, Column: 6})
+
+	sendKey(m, tcell.KeyDown, 0, tcell.ModNone)
+	sendKey(m, tcell.KeyDown, 0, tcell.ModNone)
+
+	// Column is kept on short line.
+	if m.GetCaret() != (TextPosition{Line: 2, Column: 6}) {
+		t.Errorf("Caret must be at 2, 6. Found %+v", m.GetCaret())
+	}
+
+	sendKey(m, tcell.KeyLeft, 0, tcell.ModCtrl|tcell.ModShift)
+	sendKey(m, tcell.KeyLeft, 0, tcell.ModCtrl|tcell.ModShift)
+	sendKey(m, tcell.KeyLeft, 0, tcell.ModCtrl|tcell.ModShift)
+
+	if m.GetSelectedText() != "ab\nthird " {
+		t.Errorf("Wrong selection %q", m.GetSelectedText())
+	}
+
+	sendKey(m, tcell.KeyDelete, 0, tcell.ModShift)
+
+	if c := pendingMessages(appConfig, base.WmSetClipboard); len(c) != 1 || c[0].Value != "ab\nthird " {
+		t.Errorf("Cut must put selection in clipboard. Found %+v", c)
+	}
+
+	if m.GetText() != "first line\nline" {
+		t.Errorf("Wrong text %q", m.GetText())
+	}
+
+	m.HandleMessage(base.BuildPasteMessage(m.Handler(), "a\r\nb"))
+
+	if m.GetText() != "first line\na\nbline" || m.GetCaret() != (TextPosition{Line: 2, Column: 1}) {
+		t.Errorf("Wrong text %q, caret %+v", m.GetText(), m.GetCaret())
+	}
+
+	sendKey(m, tcell.KeyEnd, 0, tcell.ModCtrl)
+	sendKey(m, tcell.KeyHome, 0, tcell.ModCtrl|tcell.ModShift)
+
+	if m.GetSelectedText() != m.GetText() {
+		t.Error("Ctrl+Shift+Home must select all")
+	}
+}
+
+func TestMemo_Draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	c := base.NewMemoryCanvas(6, 3)
+
+	m := createTestMemo(appConfig, c)
+	m.TabSize = 4
+	m.SetText("a\tb\nline two is long\n3\n4")
+	m.SetCaret(TextPosition{Line: 1, Column: 9})
+	pendingMessages(appConfig, base.WmNull)
+
+	m.HandleMessage(base.BuildDrawMessage(m.Handler()))
+
+	// Scrolled to show caret.
+	for y, line := range []string{"b     ", " two i", "      "} {
+		if l := memoryCanvasLine(c, y); l != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, line, l)
+		}
+	}
+
+	if cur := pendingMessages(appConfig, base.WmShowCursor); len(cur) != 1 || cur[0].Value.(base.Caret).X != 5 ||
+		cur[0].Value.(base.Caret).Y != 1 {
+		t.Errorf("Cursor must be shown at 5, 1. Found %+v", cur)
+	}
+
+	m.SetWordWrap(true)
+	m.SetSelection(TextPosition{Line: 1, Column: 5}, TextPosition{Line: 1, Column: 8})
+	m.Draw()
+
+	for y, line := range []string{"a   b ", "line  ", "two is"} {
+		if l := memoryCanvasLine(c, y); l != line {
+			t.Errorf("Wrapped line %d must be '%s'. Found '%s'", y, line, l)
+		}
+	}
+
+	if c.GetCell(0, 2).Style != m.GetStyle(base.RoleInputSelection) || c.GetCell(3, 2).Style != m.GetStyle(base.RoleInput) {
+		t.Error("Selection must use selection style")
+	}
+
+	// Down goes to next wrapped row.
+	sendKey(m, tcell.KeyDown, 0, tcell.ModNone)
+
+	if m.GetCaret() != (TextPosition{Line: 1, Column: 15}) || m.GetTopLine() != 1 {
+		t.Errorf("Caret must be at 1, 15. Found %+v", m.GetCaret())
+	}
+
+	// Click on "long".
+	m.HandleMessage(base.Message{
+		Handler: m.Handler(),
+		Type:    base.WmLButtonDown,
+		Value:   tcell.NewEventMouse(1, 2, tcell.Button1, tcell.ModNone),
+	})
+
+	if m.GetCaret() != (TextPosition{Line: 1, Column: 13}) {
+		t.Errorf("Click must move caret at 1, 13. Found %+v", m.GetCaret())
+	}
+}
+
+func TestMemo_wheel(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	parent := NewScrollView("parent", appConfig.Message, base.NewMemoryCanvas(6, 3))
+
+	m := createTestMemo(appConfig, base.NewMemoryCanvas(6, 3))
+	m.SetParent(&parent)
+	m.SetText("1\n2\n3\n4\n5")
+	pendingMessages(appConfig, base.WmNull)
+
+	wheel := func(buttons tcell.ButtonMask) {
+		m.HandleMessage(base.BuildMouseWheelMessage(m.Handler(), tcell.NewEventMouse(0, 0, buttons, tcell.ModNone)))
+	}
+
+	wheel(tcell.WheelDown)
+
+	if m.GetTopLine() != 2 {
+		t.Errorf("Last line must be at bottom. Found top %d", m.GetTopLine())
+	}
+
+	pendingMessages(appConfig, base.WmNull)
+	wheel(tcell.WheelDown)
+
+	if msg := pendingMessages(appConfig, base.WmMouseWheel); len(msg) != 1 || msg[0].Handler != parent.Handler() {
+		t.Errorf("Wheel at limit must be sent to parent. Found %+v", msg)
+	}
+
+	wheel(tcell.WheelUp)
+
+	if m.GetTopLine() != 0 {
+		t.Errorf("Wheel up must scroll to top. Found %d", m.GetTopLine())
+	}
+}
+
+func TestMemo_large_text(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	var b strings.Builder
+
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+
+	m := createTestMemo(appConfig, base.NewMemoryCanvas(6, 3))
+
+	if e := m.Load(strings.NewReader(b.String())); e != nil {
+		t.Fatal(e)
+	}
+
+	sendKey(m, tcell.KeyEnd, 0, tcell.ModCtrl)
+	sendKey(m, tcell.KeyUp, 0, tcell.ModNone)
+
+	for i := 0; i < 1000; i++ {
+		typeText(m, "x\n")
+		sendKey(m, tcell.KeyUp, 0, tcell.ModNone)
+		sendKey(m, tcell.KeyBackspace2, 0, tcell.ModNone)
+		pendingMessages(appConfig, base.WmNull)
+	}
+
+	sendKey(m, tcell.KeyHome, 0, tcell.ModCtrl)
+	typeText(m, "y")
+	sendKey(m, tcell.KeyEnd, 0, tcell.ModCtrl)
+	m.Draw()
+
+	if m.GetLineCount() != 100001 || m.GetTopLine() != 99998 {
+		t.Errorf("Wrong line count %d or top line %d", m.GetLineCount(), m.GetTopLine())
+	}
+}
@@ -0,0 +1,330 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"unicode"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Characters for draw menu items.
+const (
+	// MenuCheckMark mark of checked item.
+	MenuCheckMark = '✓'
+	// MenuSubmenuArrow is draw at right of item that opens a submenu.
+	MenuSubmenuArrow = '►'
+)
+
+// MenuItem is an item of menu bar or menu.
+type MenuItem struct {
+	// Caption with mnemonic mark (e.g. "&Open").
+	Caption string
+	// Text draw at right of caption (e.g. "Ctrl+O"). Key is not managed.
+	Shortcut string
+	// Command broadcast by WmCommand when item is chosen.
+	Command uint
+	// Draw check mark before caption.
+	Checked bool
+	// Item can be selected but not chosen.
+	Disabled bool
+	// Item is a line between groups of items. Other fields are ignored.
+	Separator bool
+	// Menu opened by item instead of broadcasting command.
+	Submenu *Menu
+}
+
+// Menu is a pull-down list of items opened by menu bar or by item of parent
+// menu. Keyboard is managed by menu bar.
+type Menu struct {
+	// Items of menu.
+	Items []*MenuItem
+
+	bar     *MenuBar
+	current int
+
+	Popup
+}
+
+// HandleMessage is use to manage message.
+func (m *Menu) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case m.Handler():
+		m.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		m.manageMyMessage(msg)
+
+		for _, child := range m.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range m.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GetItemIndex return selected item or -1.
+func (m *Menu) GetItemIndex() int {
+	return m.current
+}
+
+// Draw the menu.
+func (m *Menu) Draw() {
+	if !m.GetVisible() {
+		return
+	}
+
+	canvas := m.Canvas()
+	bounds := m.GetBounds()
+
+	canvas.SetBrush(m.GetStyleOf(m, base.RoleMenu))
+	canvas.DrawFrame(base.Rect{X: 0, Y: 0, Width: bounds.Width, Height: bounds.Height}, base.LineStyleSingle)
+
+	for n, item := range m.Items {
+		y := n + 1
+
+		if item.Separator {
+			canvas.DrawHLine(0, y, bounds.Width, base.LineStyleSingle)
+
+			continue
+		}
+
+		style, hot := menuItemStyles(m, item, n == m.current)
+
+		for x := 1; x < bounds.Width-1; x++ {
+			canvas.PrintCharWithBrush(x, y, ' ', style)
+		}
+
+		if item.Checked {
+			canvas.PrintCharWithBrush(2, y, MenuCheckMark, style)
+		}
+
+		drawMenuCaption(canvas, 4, y, parseMnemonic(item.Caption), style, hot)
+
+		right := menuItemRight(item)
+		canvas.PrintStringWithBrush(bounds.Width-2-base.MeasureString(right), y, right, style)
+	}
+
+	m.drawShadow()
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (m *Menu) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmClosePopup, base.WmLButtonDown, base.WmLButtonUp, base.WmMouseDrag:
+		if m.GetOnReceiveMessage() != nil && m.GetOnReceiveMessage()(m, msg) {
+			return
+		}
+
+		m.manageMenuMessage(msg)
+	default:
+		msg.Handler = m.Handler()
+		m.View.HandleMessage(msg)
+	}
+}
+
+func (m *Menu) manageMenuMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if m.GetOnDraw() != nil {
+			m.GetOnDraw()(m)
+		} else {
+			m.Draw()
+		}
+
+		return
+	}
+
+	if m.bar == nil {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmClosePopup:
+		// Submenus are closed too.
+		m.bar.closeMenus(m.bar.level(m))
+	case base.WmLButtonDown:
+		if n := m.itemAt(msg.Value.(*tcell.EventMouse).Position()); n >= 0 {
+			m.bar.selectItem(m, n)
+		}
+	case base.WmMouseDrag:
+		m.bar.track(msg.Value.(*tcell.EventMouse).Position())
+	case base.WmLButtonUp:
+		if n := m.itemAt(msg.Value.(*tcell.EventMouse).Position()); n >= 0 && n == m.current &&
+			m.Items[n].Submenu == nil {
+			m.bar.choose(m.Items[n])
+		}
+	}
+}
+
+// Open menu at screen position (x, y). Menu stays in width of menu bar.
+func (m *Menu) open(bar *MenuBar, owner base.TView, x, y int) {
+	width, height := m.size()
+	barBounds := bar.GetBounds()
+
+	if right := barBounds.X + barBounds.Width; x+width > right {
+		x = base.MaxInt(right-width, 0)
+	}
+
+	m.bar = bar
+	m.SetOwner(owner)
+	m.SetBounds(base.Rect{X: x, Y: y, Width: width, Height: height})
+	m.current = m.next(-1, 1)
+	m.show(m)
+}
+
+// Size of border and items.
+func (m *Menu) size() (int, int) {
+	caption := 0
+	right := 0
+
+	for _, item := range m.Items {
+		if !item.Separator {
+			caption = base.MaxInt(caption, base.MeasureString(parseMnemonic(item.Caption).text))
+			right = base.MaxInt(right, base.MeasureString(menuItemRight(item)))
+		}
+	}
+
+	// Border, space, check mark, space, caption, space, border.
+	width := caption + 6
+
+	if right > 0 {
+		width += right + 2
+	}
+
+	return width, len(m.Items) + 2
+}
+
+// Return item under screen position or -1 (border, separator).
+func (m *Menu) itemAt(x, y int) int {
+	bounds := m.GetBounds()
+	n := y - bounds.Y - 1
+
+	if x <= bounds.X || x >= bounds.X+bounds.Width-1 || n < 0 || n >= len(m.Items) || m.Items[n].Separator {
+		return -1
+	}
+
+	return n
+}
+
+// Return next item from item `from` in direction `delta` (see
+// nextMenuItem).
+func (m *Menu) next(from int, delta int) int {
+	return nextMenuItem(m.Items, from, delta)
+}
+
+func (m *Menu) setCurrent(n int) {
+	if n != m.current {
+		m.current = n
+		m.Invalidate()
+	}
+}
+
+//------------------------------------------------------------------------------
+// Menu item helpers.
+
+// Style of item and of its mnemonic letter.
+func menuItemStyles(v styledView, item *MenuItem, selected bool) (tcell.Style, tcell.Style) {
+	switch {
+	case selected:
+		st := v.GetStyleOf(v, base.RoleMenuSelected)
+
+		return st, st
+	case item.Disabled:
+		st := v.GetStyleOf(v, base.RoleMenuDisabled)
+
+		return st, st
+	}
+
+	return v.GetStyleOf(v, base.RoleMenu), v.GetStyleOf(v, base.RoleMenuShortcut)
+}
+
+// Text draw at right of item.
+func menuItemRight(item *MenuItem) string {
+	if item.Submenu != nil {
+		return string(MenuSubmenuArrow)
+	}
+
+	return item.Shortcut
+}
+
+// Draw caption without mnemonic mark. Mnemonic letter use style `hot`. Return
+// width.
+func drawMenuCaption(canvas base.TCanvas, x int, y int, m mnemonic, style tcell.Style, hot tcell.Style) int {
+	start := x
+
+	for i, g := range base.SplitGraphemes(m.text) {
+		st := style
+
+		if i == m.index {
+			st = hot
+		}
+
+		canvas.PrintCellWithBrush(x, y, g.Char, g.Combining, st)
+		x += g.Width
+	}
+
+	return x - start
+}
+
+// Return next item that is not a separator from item `from` in direction
+// `delta`. Search wraps. Return -1 if there is no item.
+func nextMenuItem(items []*MenuItem, from int, delta int) int {
+	count := len(items)
+
+	for i := 1; i <= count; i++ {
+		n := ((from+delta*i)%count + count) % count
+
+		if !items[n].Separator {
+			return n
+		}
+	}
+
+	return -1
+}
+
+// Return first item with mnemonic `r` (case insensitive) or -1.
+func menuItemByMnemonic(items []*MenuItem, r rune) int {
+	r = unicode.ToLower(r)
+
+	for n, item := range items {
+		if !item.Separator && parseMnemonic(item.Caption).key == r {
+			return n
+		}
+	}
+
+	return -1
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewMenu create new empty menu with shadow. Menu is draw on root canvas of
+// `parentCanvas` (screen).
+func NewMenu(name string, message base.Bus, parentCanvas base.TCanvas) Menu {
+	return Menu{
+		current: -1,
+		Popup:   NewPopup(name, message, base.RootCanvas(parentCanvas)),
+	}
+}
@@ -0,0 +1,483 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// MenuBar is a line of items opening pull-down menus, usually at top of
+// screen. It is in popup layer: above all windows and never activated.
+//
+// F10 or Alt+mnemonic open a menu. Arrow keys navigate, mnemonic or Enter
+// choose item, Esc close menu. Mouse can be dragged across bar and menus.
+// Chosen item broadcast WmCommand with its command.
+type MenuBar struct {
+	// Items of bar. Item with submenu opens it.
+	Items []*MenuItem
+
+	active  bool
+	current int
+	// Mouse pressed on item with opened menu: release closes it.
+	closeOnRelease bool
+	// Opened menus, from menu of bar to deepest submenu.
+	menus []*Menu
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (b *MenuBar) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case b.Handler():
+		b.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		b.manageMyMessage(msg)
+
+		for _, child := range b.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range b.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GetOwner return nil: menu bar is not opened by a view.
+func (b *MenuBar) GetOwner() base.TView {
+	return nil
+}
+
+// PreviewKey manage F10 and Alt+mnemonic before active window. When bar is
+// active, it manages all keys.
+func (b *MenuBar) PreviewKey(ev *tcell.EventKey) bool {
+	if !b.GetEnabled() {
+		return false
+	}
+
+	if b.active {
+		b.manageKey(ev)
+
+		return true
+	}
+
+	n := -1
+
+	switch {
+	case ev.Key() == tcell.KeyF10:
+		n = nextMenuItem(b.Items, -1, 1)
+	case ev.Key() == tcell.KeyRune && ev.Modifiers()&tcell.ModAlt != 0:
+		n = menuItemByMnemonic(b.Items, ev.Rune())
+	}
+
+	if n < 0 {
+		return false
+	}
+
+	b.OpenMenu(n)
+
+	return true
+}
+
+// OpenMenu select item `n` of bar and open its menu. Keys are managed by bar
+// until menus are closed.
+func (b *MenuBar) OpenMenu(n int) {
+	if n < 0 || n >= len(b.Items) || b.Items[n].Separator {
+		return
+	}
+
+	b.closeMenus(0)
+
+	b.active = true
+	b.current = n
+	b.Invalidate()
+
+	if item := b.Items[n]; item.Submenu != nil && !item.Disabled {
+		bounds := base.AbsoluteBounds(b)
+		x, _ := b.titleBounds(n)
+
+		item.Submenu.open(b, b, bounds.X+x, bounds.Y+1)
+		b.menus = append(b.menus, item.Submenu)
+	}
+}
+
+// CloseMenus close all menus. Keys are sent to active window again.
+func (b *MenuBar) CloseMenus() {
+	b.closeMenus(0)
+
+	if b.active {
+		b.active = false
+		b.current = -1
+		b.Invalidate()
+	}
+}
+
+// GetActive return true if bar manages keys.
+func (b *MenuBar) GetActive() bool {
+	return b.active
+}
+
+// GetItemIndex return selected item of bar or -1.
+func (b *MenuBar) GetItemIndex() int {
+	return b.current
+}
+
+// GetOpenedMenus return opened menus, from menu of bar to deepest submenu.
+func (b *MenuBar) GetOpenedMenus() []*Menu {
+	menus := make([]*Menu, len(b.menus))
+
+	copy(menus, b.menus)
+
+	return menus
+}
+
+// Draw the menu bar.
+func (b *MenuBar) Draw() {
+	if !b.GetVisible() {
+		return
+	}
+
+	canvas := b.Canvas()
+	bounds := b.GetBounds()
+	style := b.GetStyleOf(b, base.RoleMenu)
+
+	for y := 0; y < bounds.Height; y++ {
+		for x := 0; x < bounds.Width; x++ {
+			canvas.PrintCharWithBrush(x, y, ' ', style)
+		}
+	}
+
+	for n, item := range b.Items {
+		if item.Separator {
+			continue
+		}
+
+		x, width := b.titleBounds(n)
+		st, hot := menuItemStyles(b, item, b.active && n == b.current)
+
+		for i := 0; i < width; i++ {
+			canvas.PrintCharWithBrush(x+i, 0, ' ', st)
+		}
+
+		drawMenuCaption(canvas, x+1, 0, parseMnemonic(item.Caption), st, hot)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (b *MenuBar) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmClosePopup, base.WmLButtonDown, base.WmLButtonUp, base.WmMouseDrag:
+		if b.GetOnReceiveMessage() != nil && b.GetOnReceiveMessage()(b, msg) {
+			return
+		}
+
+		b.manageMenuBarMessage(msg)
+	default:
+		msg.Handler = b.Handler()
+		b.View.HandleMessage(msg)
+	}
+}
+
+func (b *MenuBar) manageMenuBarMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if b.GetOnDraw() != nil {
+			b.GetOnDraw()(b)
+		} else {
+			b.Draw()
+		}
+
+		return
+	}
+
+	if !b.GetEnabled() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmClosePopup:
+		b.CloseMenus()
+	case base.WmLButtonDown:
+		n := b.titleAt(msg.Value.(*tcell.EventMouse).Position())
+
+		// Click on opened menu closes it, unless mouse is dragged in menu.
+		b.closeOnRelease = n >= 0 && b.active && n == b.current && len(b.menus) > 0
+
+		if n < 0 {
+			b.CloseMenus()
+		} else if !b.closeOnRelease {
+			b.OpenMenu(n)
+		}
+	case base.WmMouseDrag:
+		b.track(msg.Value.(*tcell.EventMouse).Position())
+	case base.WmLButtonUp:
+		n := b.titleAt(msg.Value.(*tcell.EventMouse).Position())
+
+		switch {
+		case n < 0 || !b.active || n != b.current:
+		case b.closeOnRelease:
+			b.CloseMenus()
+		case b.Items[n].Submenu == nil:
+			// Item of bar without menu.
+			b.choose(b.Items[n])
+		}
+
+		b.closeOnRelease = false
+	}
+}
+
+func (b *MenuBar) manageKey(ev *tcell.EventKey) {
+	m := b.deepest()
+	opened := len(b.menus) > 0
+
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		if len(b.menus) > 1 {
+			b.closeMenus(len(b.menus) - 1)
+		} else {
+			b.CloseMenus()
+		}
+	case tcell.KeyF10:
+		b.CloseMenus()
+	case tcell.KeyLeft:
+		if len(b.menus) > 1 {
+			b.closeMenus(len(b.menus) - 1)
+		} else {
+			b.selectTitle(nextMenuItem(b.Items, b.current, -1), opened)
+		}
+	case tcell.KeyRight:
+		if m != nil && m.current >= 0 && m.Items[m.current].Submenu != nil && !m.Items[m.current].Disabled {
+			b.openSubmenu(m)
+		} else {
+			b.selectTitle(nextMenuItem(b.Items, b.current, 1), opened)
+		}
+	case tcell.KeyUp, tcell.KeyDown:
+		delta := 1
+
+		if ev.Key() == tcell.KeyUp {
+			delta = -1
+		}
+
+		if m == nil {
+			b.OpenMenu(b.current)
+		} else {
+			m.setCurrent(m.next(m.current, delta))
+		}
+	case tcell.KeyHome, tcell.KeyEnd:
+		if m != nil && ev.Key() == tcell.KeyHome {
+			m.setCurrent(m.next(-1, 1))
+		} else if m != nil {
+			m.setCurrent(m.next(0, -1))
+		}
+	case tcell.KeyEnter:
+		b.enter(m)
+	case tcell.KeyRune:
+		if m == nil {
+			b.OpenMenu(menuItemByMnemonic(b.Items, ev.Rune()))
+		} else if n := menuItemByMnemonic(m.Items, ev.Rune()); n >= 0 {
+			m.setCurrent(n)
+			b.enter(m)
+		}
+	}
+}
+
+// Select item of bar. Its menu is opened if `open`.
+func (b *MenuBar) selectTitle(n int, open bool) {
+	if open {
+		b.OpenMenu(n)
+	} else if n >= 0 {
+		b.current = n
+		b.Invalidate()
+	}
+}
+
+// Choose selected item of menu `m` or of bar if `m` is nil.
+func (b *MenuBar) enter(m *Menu) {
+	switch {
+	case m == nil && b.current >= 0:
+		if b.Items[b.current].Submenu != nil {
+			b.OpenMenu(b.current)
+		} else {
+			b.choose(b.Items[b.current])
+		}
+	case m != nil && m.current >= 0:
+		if item := m.Items[m.current]; item.Submenu == nil {
+			b.choose(item)
+		} else if !item.Disabled {
+			b.openSubmenu(m)
+		}
+	}
+}
+
+// Close all menus and broadcast command of item.
+func (b *MenuBar) choose(item *MenuItem) {
+	if item.Disabled || item.Separator {
+		return
+	}
+
+	b.CloseMenus()
+	b.GetMessageBus().Send(base.BuildCommandMessage(item.Command))
+}
+
+// Select item `n` of menu `m`, close deeper menus and open submenu of item.
+func (b *MenuBar) selectItem(m *Menu, n int) {
+	b.closeMenus(b.level(m) + 1)
+	m.setCurrent(n)
+
+	if item := m.Items[n]; item.Submenu != nil && !item.Disabled {
+		b.openSubmenu(m)
+	}
+}
+
+// Open submenu of selected item of menu `m` at right of menu.
+func (b *MenuBar) openSubmenu(m *Menu) {
+	sub := m.Items[m.current].Submenu
+
+	// Menu can't be opened twice.
+	if b.level(sub) < len(b.menus) {
+		return
+	}
+
+	b.closeMenus(b.level(m) + 1)
+
+	bounds := m.GetBounds()
+
+	sub.open(b, m, bounds.X+bounds.Width, bounds.Y+m.current)
+	b.menus = append(b.menus, sub)
+}
+
+// Close menus from `level` to deepest submenu.
+func (b *MenuBar) closeMenus(level int) {
+	for i := len(b.menus) - 1; i >= level; i-- {
+		b.menus[i].hide(b.menus[i])
+	}
+
+	if level < len(b.menus) {
+		b.menus = b.menus[:level]
+	}
+}
+
+// Return index of menu in opened menus or number of opened menus.
+func (b *MenuBar) level(m *Menu) int {
+	for i, opened := range b.menus {
+		if opened == m {
+			return i
+		}
+	}
+
+	return len(b.menus)
+}
+
+// Return deepest opened menu or nil.
+func (b *MenuBar) deepest() *Menu {
+	if len(b.menus) == 0 {
+		return nil
+	}
+
+	return b.menus[len(b.menus)-1]
+}
+
+// Select item under mouse dragged at screen position (x, y).
+func (b *MenuBar) track(x, y int) {
+	for k := len(b.menus) - 1; k >= 0; k-- {
+		m := b.menus[k]
+
+		if n := m.itemAt(x, y); n >= 0 {
+			if !b.tracked(k, n) {
+				b.closeOnRelease = false
+				b.selectItem(m, n)
+			}
+
+			return
+		}
+	}
+
+	if n := b.titleAt(x, y); n >= 0 && (n != b.current || len(b.menus) == 0) {
+		b.closeOnRelease = false
+		b.OpenMenu(n)
+	}
+}
+
+// Return true if item `n` of opened menu `k` is selected and its submenu
+// (if any) is the only deeper menu.
+func (b *MenuBar) tracked(k int, n int) bool {
+	m := b.menus[k]
+
+	if m.current != n {
+		return false
+	}
+
+	if sub := m.Items[n].Submenu; sub != nil && !m.Items[n].Disabled {
+		return k+2 == len(b.menus) && b.menus[k+1] == sub
+	}
+
+	return k+1 == len(b.menus)
+}
+
+// Position and width of item `n` in bar.
+func (b *MenuBar) titleBounds(n int) (int, int) {
+	x := 1
+
+	for i := 0; i < n; i++ {
+		x += base.MeasureString(parseMnemonic(b.Items[i].Caption).text) + 2
+	}
+
+	return x, base.MeasureString(parseMnemonic(b.Items[n].Caption).text) + 2
+}
+
+// Return item of bar at screen position or -1.
+func (b *MenuBar) titleAt(x, y int) int {
+	bounds := base.AbsoluteBounds(b)
+
+	if y != bounds.Y {
+		return -1
+	}
+
+	for n, item := range b.Items {
+		tx, width := b.titleBounds(n)
+
+		if !item.Separator && x >= bounds.X+tx && x < bounds.X+tx+width {
+			return n
+		}
+	}
+
+	return -1
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewMenuBar create new menu bar without items. Add it to application like a
+// window.
+func NewMenuBar(name string, message base.Bus, parentCanvas base.TCanvas) MenuBar {
+	b := MenuBar{
+		current: -1,
+		View:    base.NewView(name, message, parentCanvas),
+	}
+
+	b.SetEnabled(true)
+	b.SetVisible(true)
+
+	return b
+}
@@ -0,0 +1,287 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func TestMenuBar_draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(30, 10)
+	b := createTestMenuBar(appConfig, m)
+	b.OpenMenu(1)
+	b.HandleMessage(base.BuildDrawMessage(b.Handler()))
+
+	if s := memoryCanvasLine(m, 0); s != "  File  Edit  Help            " {
+		t.Errorf("Bar must draw items. Found '%s'", s)
+	}
+
+	if m.GetCell(7, 0).Style != b.GetStyle(base.RoleMenuSelected) || m.GetCell(1, 0).Style != b.GetStyle(base.RoleMenu) {
+		t.Error("Selected item must use selected style")
+	}
+
+	if m.GetCell(2, 0).Style != b.GetStyle(base.RoleMenuShortcut) {
+		t.Error("Mnemonic letter must use shortcut style")
+	}
+}
+
+func TestMenuBar_activate(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	b := createTestMenuBar(appConfig, base.NewMemoryCanvas(30, 10))
+
+	if b.PreviewKey(keyEvent(tcell.KeyRune, 'e', tcell.ModNone)) || b.PreviewKey(keyEvent(tcell.KeyRune, 'z', tcell.ModAlt)) {
+		t.Error("Inactive bar manages only F10 and Alt+mnemonic")
+	}
+
+	if !b.PreviewKey(keyEvent(tcell.KeyRune, 'E', tcell.ModAlt)) || b.GetItemIndex() != 1 || !b.Items[1].Submenu.GetOpened() {
+		t.Error("Alt+mnemonic must open menu")
+	}
+
+	// Undo is disabled but can be selected.
+	if !b.PreviewKey(keyEvent(tcell.KeyRune, 'x', tcell.ModNone)) || b.Items[1].Submenu.GetItemIndex() != 0 {
+		t.Error("Active bar manages all keys")
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyEscape, 0, tcell.ModNone))
+
+	if b.GetActive() || b.Items[1].Submenu.GetOpened() {
+		t.Error("Esc must close menu")
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyF10, 0, tcell.ModNone))
+
+	if !b.GetActive() || b.GetItemIndex() != 0 || !b.Items[0].Submenu.GetOpened() {
+		t.Error("F10 must open first menu")
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyF10, 0, tcell.ModNone))
+
+	if b.GetActive() || b.Items[0].Submenu.GetOpened() {
+		t.Error("F10 must close menu")
+	}
+}
+
+func TestMenuBar_keyboard_navigation(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	b := createTestMenuBar(appConfig, base.NewMemoryCanvas(30, 10))
+	file := b.Items[0].Submenu
+	recent := file.Items[2].Submenu
+
+	b.OpenMenu(0)
+	b.PreviewKey(keyEvent(tcell.KeyDown, 0, tcell.ModNone))
+
+	if file.GetItemIndex() != 2 {
+		t.Errorf("Down must skip separator. Found %d", file.GetItemIndex())
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyRight, 0, tcell.ModNone))
+
+	if menus := b.GetOpenedMenus(); len(menus) != 2 || menus[1] != recent {
+		t.Fatal("Right must open submenu")
+	}
+
+	if bounds := recent.GetBounds(); bounds != (base.Rect{X: 19, Y: 3, Width: 11, Height: 3}) {
+		t.Errorf("Submenu must be at right of item, in bar width. Found %v", bounds)
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyLeft, 0, tcell.ModNone))
+
+	if recent.GetOpened() || !file.GetOpened() {
+		t.Error("Left must close submenu")
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyLeft, 0, tcell.ModNone))
+
+	if b.GetItemIndex() != 2 || file.GetOpened() || len(b.GetOpenedMenus()) != 0 {
+		t.Error("Left must select previous item of bar")
+	}
+
+	// Only bar is selected: Down opens menu.
+	b.PreviewKey(keyEvent(tcell.KeyRight, 0, tcell.ModNone))
+	b.PreviewKey(keyEvent(tcell.KeyDown, 0, tcell.ModNone))
+	b.PreviewKey(keyEvent(tcell.KeyEnd, 0, tcell.ModNone))
+
+	if b.GetItemIndex() != 0 || file.GetItemIndex() != 3 {
+		t.Errorf("End must select last item of File menu. Found %d, %d", b.GetItemIndex(), file.GetItemIndex())
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyRune, 'r', tcell.ModNone))
+	sentCommands(appConfig)
+	b.PreviewKey(keyEvent(tcell.KeyRune, 'a', tcell.ModNone))
+
+	if c := sentCommands(appConfig); len(c) != 1 || c[0] != testCmdRecent || b.GetActive() {
+		t.Errorf("Mnemonic must choose item. Found %v", c)
+	}
+
+	b.OpenMenu(1)
+	b.PreviewKey(keyEvent(tcell.KeyEnter, 0, tcell.ModNone))
+
+	if c := sentCommands(appConfig); len(c) != 0 || !b.GetActive() {
+		t.Error("Disabled item can't be chosen")
+	}
+
+	b.PreviewKey(keyEvent(tcell.KeyUp, 0, tcell.ModNone))
+	b.PreviewKey(keyEvent(tcell.KeyEnter, 0, tcell.ModNone))
+
+	if c := sentCommands(appConfig); len(c) != 1 || c[0] != testCmdWrap {
+		t.Errorf("Enter must choose item. Found %v", c)
+	}
+
+	// Item of bar without menu.
+	b.PreviewKey(keyEvent(tcell.KeyRune, 'h', tcell.ModAlt))
+	sentCommands(appConfig)
+	b.PreviewKey(keyEvent(tcell.KeyEnter, 0, tcell.ModNone))
+
+	if c := sentCommands(appConfig); len(c) != 1 || c[0] != testCmdHelp {
+		t.Errorf("Enter must choose item of bar. Found %v", c)
+	}
+}
+
+func TestMenuBar_mouse_tracking(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	b := createTestMenuBar(appConfig, base.NewMemoryCanvas(30, 10))
+	file := b.Items[0].Submenu
+	edit := b.Items[1].Submenu
+
+	mouse := func(x, y int, msgType uint) {
+		ev := tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone)
+
+		if msgType == base.WmMouseDrag {
+			b.HandleMessage(base.BuildMouseDragMessage(b.Handler(), ev))
+		} else {
+			b.HandleMessage(base.BuildClickMouseMessage(b.Handler(), ev, msgType))
+		}
+	}
+
+	mouse(2, 0, base.WmLButtonDown)
+
+	if !file.GetOpened() {
+		t.Fatal("Click on item must open menu")
+	}
+
+	mouse(8, 0, base.WmMouseDrag)
+
+	if file.GetOpened() || !edit.GetOpened() || b.GetItemIndex() != 1 {
+		t.Error("Drag on bar must open menu under mouse")
+	}
+
+	mouse(2, 0, base.WmMouseDrag)
+	mouse(3, 4, base.WmMouseDrag)
+
+	if file.GetItemIndex() != 2 || !file.Items[2].Submenu.GetOpened() {
+		t.Error("Drag on item must select it and open its submenu")
+	}
+
+	mouse(3, 5, base.WmMouseDrag)
+
+	if file.GetItemIndex() != 3 || file.Items[2].Submenu.GetOpened() {
+		t.Error("Drag on other item must close submenu")
+	}
+
+	// Click on opened menu closes it.
+	mouse(2, 0, base.WmLButtonUp)
+	mouse(2, 0, base.WmLButtonDown)
+
+	if !file.GetOpened() {
+		t.Error("Menu stays opened while mouse is pressed")
+	}
+
+	mouse(2, 0, base.WmLButtonUp)
+
+	if b.GetActive() || file.GetOpened() {
+		t.Error("Click on item of opened menu must close it")
+	}
+
+	mouse(14, 0, base.WmLButtonDown)
+	sentCommands(appConfig)
+	mouse(14, 0, base.WmLButtonUp)
+
+	if c := sentCommands(appConfig); len(c) != 1 || c[0] != testCmdHelp {
+		t.Errorf("Click on item without menu must send command. Found %v", c)
+	}
+
+	b.OpenMenu(0)
+	b.HandleMessage(base.BuildClosePopupMessage(b.Handler()))
+
+	if b.GetActive() || file.GetOpened() {
+		t.Error("Click outside must close menus")
+	}
+}
+
+func TestMenuBar_application(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := base.NewApplication(appConfig)
+
+	window := base.NewView("window", appConfig.Message, app.Canvas())
+	window.SetEnabled(true)
+	window.SetVisible(true)
+	window.SetBounds(base.Rect{X: 0, Y: 0, Width: 30, Height: 10})
+
+	var commands []uint
+	window.SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		if msg.Type == base.WmCommand {
+			commands = append(commands, msg.Value.(uint))
+		}
+
+		return false
+	})
+
+	b := createTestMenuBar(appConfig, base.NewMemoryCanvas(30, 10))
+
+	app.AddWindow(&window)
+	app.AddWindow(b)
+	app.Init()
+	app.Start()
+	app.Step()
+
+	if app.ActiveWindow() != &window {
+		t.Error("Menu bar can't be activated")
+	}
+
+	appConfig.Message.Send(base.BuildKeyMessage(keyEvent(tcell.KeyF10, 0, tcell.ModNone)))
+	app.Step()
+
+	if wl := app.WindowsList(); len(wl) != 3 || wl[0] != b.Items[0].Submenu {
+		t.Fatalf("Menu must be above windows. Found %v", wl)
+	}
+
+	// Press on bar, drag to Exit and release.
+	for _, ev := range []*tcell.EventMouse{
+		tcell.NewEventMouse(2, 0, tcell.Button1, tcell.ModNone),
+		tcell.NewEventMouse(3, 3, tcell.Button1, tcell.ModNone),
+		tcell.NewEventMouse(3, 5, tcell.Button1, tcell.ModNone),
+		tcell.NewEventMouse(3, 5, tcell.ButtonNone, tcell.ModNone),
+	} {
+		appConfig.Message.Send(base.BuildMouseMessage(ev))
+		app.Step()
+	}
+
+	if len(commands) != 1 || commands[0] != testCmdExit {
+		t.Errorf("Window must receive command. Found %v", commands)
+	}
+
+	if wl := app.WindowsList(); len(wl) != 2 || b.GetActive() {
+		t.Errorf("Menus must be closed. Found %v", wl)
+	}
+}
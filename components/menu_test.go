@@ -0,0 +1,156 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Commands of test menu bar.
+const (
+	testCmdOpen uint = iota + 1
+	testCmdRecent
+	testCmdExit
+	testCmdUndo
+	testCmdWrap
+	testCmdHelp
+)
+
+// Bar "File Edit Help". File menu has separator and Recent submenu, Undo is
+// disabled and Help has no menu.
+func createTestMenuBar(appConfig base.ApplicationConfig, m *base.MemoryCanvas) *MenuBar {
+	recent := NewMenu("recent", appConfig.Message, m)
+	recent.Items = []*MenuItem{{Caption: "&a.txt", Command: testCmdRecent}}
+
+	file := NewMenu("file", appConfig.Message, m)
+	file.Items = []*MenuItem{
+		{Caption: "&Open", Shortcut: "Ctrl+O", Command: testCmdOpen},
+		{Separator: true},
+		{Caption: "&Recent", Submenu: &recent},
+		{Caption: "E&xit", Command: testCmdExit},
+	}
+
+	edit := NewMenu("edit", appConfig.Message, m)
+	edit.Items = []*MenuItem{
+		{Caption: "&Undo", Command: testCmdUndo, Disabled: true},
+		{Caption: "&Wrap", Command: testCmdWrap, Checked: true},
+	}
+
+	b := NewMenuBar("menu", appConfig.Message, m)
+	b.SetBounds(base.Rect{X: 0, Y: 0, Width: 30, Height: 1})
+	b.Items = []*MenuItem{
+		{Caption: "&File", Submenu: &file},
+		{Caption: "&Edit", Submenu: &edit},
+		{Caption: "&Help", Command: testCmdHelp},
+	}
+
+	return &b
+}
+
+// Return commands sent by bus. Other messages are removed.
+func sentCommands(appConfig base.ApplicationConfig) []uint {
+	var commands []uint
+
+	for _, msg := range pendingMessages(appConfig, base.WmCommand) {
+		commands = append(commands, msg.Value.(uint))
+	}
+
+	return commands
+}
+
+func TestMenu_draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(30, 10)
+	b := createTestMenuBar(appConfig, m)
+	b.OpenMenu(0)
+
+	file := b.Items[0].Submenu
+
+	if bounds := file.GetBounds(); bounds != (base.Rect{X: 1, Y: 1, Width: 20, Height: 6}) {
+		t.Errorf("Menu must be under its item. Found %v", bounds)
+	}
+
+	b.Items[0].Submenu.Items[3].Checked = true
+	file.HandleMessage(base.BuildDrawMessage(file.Handler()))
+
+	for y, line := range []string{
+		"┌──────────────────┐",
+		"│   Open    Ctrl+O │",
+		"├──────────────────┤",
+		"│   Recent       ► │",
+		"│ ✓ Exit           │",
+		"└──────────────────┘",
+	} {
+		if s := string([]rune(memoryCanvasLine(m, y+1))[1:21]); s != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y+1, line, s)
+		}
+	}
+
+	if m.GetCell(2, 2).Style != file.GetStyle(base.RoleMenuSelected) {
+		t.Error("First item must be selected")
+	}
+
+	if m.GetCell(5, 4).Style != file.GetStyle(base.RoleMenuShortcut) {
+		t.Error("Mnemonic letter must use shortcut style")
+	}
+
+	if m.GetCell(21, 2).Style == m.GetCell(29, 9).Style {
+		t.Error("Menu must cast a shadow")
+	}
+}
+
+func TestMenu_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	b := createTestMenuBar(appConfig, base.NewMemoryCanvas(30, 10))
+	b.OpenMenu(0)
+
+	file := b.Items[0].Submenu
+
+	click := func(x, y int, msgType uint) {
+		ev := tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone)
+		file.HandleMessage(base.BuildClickMouseMessage(file.Handler(), ev, msgType))
+	}
+
+	// Separator and border can't be selected.
+	click(5, 3, base.WmLButtonDown)
+	click(1, 2, base.WmLButtonDown)
+
+	if file.GetItemIndex() != 0 {
+		t.Errorf("Separator must not be selected. Found %d", file.GetItemIndex())
+	}
+
+	click(5, 4, base.WmLButtonDown)
+
+	if len(b.GetOpenedMenus()) != 2 || file.GetItemIndex() != 2 {
+		t.Error("Click on item must open its submenu")
+	}
+
+	sentCommands(appConfig)
+	click(5, 5, base.WmLButtonDown)
+	click(5, 5, base.WmLButtonUp)
+
+	if c := sentCommands(appConfig); len(c) != 1 || c[0] != testCmdExit {
+		t.Errorf("Click on item must send command. Found %v", c)
+	}
+
+	if b.GetActive() || file.GetOpened() || len(b.GetOpenedMenus()) != 0 {
+		t.Error("Chosen item must close menus")
+	}
+}
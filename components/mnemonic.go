@@ -0,0 +1,81 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+	"unicode"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// MnemonicMark is put before letter of caption to underline. Double it to
+// display it.
+const MnemonicMark = '&'
+
+// Caption without mark and letter to underline.
+type mnemonic struct {
+	// Caption without mark.
+	text string
+	// Index of grapheme to underline (new lines are not counted), -1 if none.
+	index int
+	// Letter in lower case, 0 if none.
+	key rune
+}
+
+// Return true if event is Alt+letter.
+func (m mnemonic) match(ev *tcell.EventKey) bool {
+	return m.key != 0 &&
+		ev.Key() == tcell.KeyRune &&
+		ev.Modifiers()&tcell.ModAlt != 0 &&
+		unicode.ToLower(ev.Rune()) == m.key
+}
+
+// Style of grapheme `index`.
+func (m mnemonic) style(index int, st tcell.Style) tcell.Style {
+	if index == m.index {
+		return st.Underline(true)
+	}
+
+	return st
+}
+
+func parseMnemonic(caption string) mnemonic {
+	var b strings.Builder
+
+	m := mnemonic{
+		index: -1,
+	}
+
+	runes := []rune(caption)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == MnemonicMark && i+1 < len(runes) {
+			i++
+
+			if runes[i] != MnemonicMark && m.key == 0 {
+				m.index = len(base.SplitGraphemes(b.String()))
+				m.key = unicode.ToLower(runes[i])
+			}
+		}
+
+		b.WriteRune(runes[i])
+	}
+
+	m.text = b.String()
+
+	return m
+}
@@ -0,0 +1,42 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ModalResult is result of dialog, set by button that close it.
+type ModalResult int
+
+const (
+	// ModalResultNone dialog is not closed.
+	ModalResultNone ModalResult = iota
+	// ModalResultOK dialog is accepted.
+	ModalResultOK
+	// ModalResultCancel dialog is canceled.
+	ModalResultCancel
+	// ModalResultYes answer is yes.
+	ModalResultYes
+	// ModalResultNo answer is no.
+	ModalResultNo
+	// ModalResultAbort operation is aborted.
+	ModalResultAbort
+	// ModalResultRetry operation must be retried.
+	ModalResultRetry
+	// ModalResultIgnore error is ignored.
+	ModalResultIgnore
+	// ModalResultClose dialog is closed.
+	ModalResultClose
+)
+
+// OnModalResult is call when modal result of window is set.
+type OnModalResult func(*Window, ModalResult)
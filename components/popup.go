@@ -0,0 +1,209 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// OnPopupClose is call when popup is closed.
+type OnPopupClose func(*Popup)
+
+// Popup is a top-level view above all windows, outside of owner clip area
+// (drop-down list, menu). It is closed when mouse is pressed outside. Bounds
+// are screen coordinates.
+type Popup struct {
+	// Draw shadow.
+	Shadow bool
+	// Call when popup is closed.
+	OnClose OnPopupClose
+
+	owner  base.TView
+	opened bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (p *Popup) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case p.Handler():
+		p.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		p.manageMyMessage(msg)
+
+		for _, child := range p.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range p.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetOwner change view that opened popup.
+func (p *Popup) SetOwner(owner base.TView) {
+	p.owner = owner
+}
+
+// GetOwner return view that opened popup.
+func (p *Popup) GetOwner() base.TView {
+	return p.owner
+}
+
+// GetShadow return true if popup cast a shadow.
+func (p *Popup) GetShadow() bool {
+	return p.Shadow
+}
+
+// GetOpened return true if popup is shown.
+func (p *Popup) GetOpened() bool {
+	return p.opened
+}
+
+// Open add popup above all windows.
+func (p *Popup) Open() {
+	p.show(p)
+}
+
+// Close remove popup and call OnClose.
+func (p *Popup) Close() {
+	if p.hide(p) && p.OnClose != nil {
+		p.OnClose(p)
+	}
+}
+
+// Invalidate ask application to repaint popup and its shadow at next frame.
+func (p *Popup) Invalidate() {
+	p.GetMessageBus().Send(base.BuildInvalidateMessage(base.PaintBounds(p)))
+}
+
+// Draw the popup.
+func (p *Popup) Draw() {
+	if !p.GetVisible() {
+		return
+	}
+
+	p.View.Draw()
+	p.drawShadow()
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Add view `v` (popup or view embedding popup) above all windows.
+func (p *Popup) show(v base.TView) {
+	if p.opened {
+		return
+	}
+
+	p.opened = true
+
+	p.GetMessageBus().Send(base.Message{
+		Handler: base.ApplicationHandler(),
+		Type:    base.WmCreate,
+		Value:   v,
+	})
+
+	p.Invalidate()
+}
+
+// Remove view `v` added by show. Return false if already closed.
+func (p *Popup) hide(v base.TView) bool {
+	if !p.opened {
+		return false
+	}
+
+	p.opened = false
+
+	p.GetMessageBus().Send(base.Message{
+		Handler: base.ApplicationHandler(),
+		Type:    base.WmDestroy,
+		Value:   v,
+	})
+
+	// Repaint views under popup.
+	p.Invalidate()
+
+	return true
+}
+
+func (p *Popup) drawShadow() {
+	if p.Shadow {
+		base.DrawShadowWithBrush(base.RootCanvas(p.Canvas()), p.GetBounds(), p.GetStyle(base.RoleShadow))
+	}
+}
+
+// Manage message if it's for me.
+func (p *Popup) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if p.GetOnReceiveMessage() != nil && p.GetOnReceiveMessage()(p, msg) {
+			return
+		}
+
+		if p.GetOnDraw() != nil {
+			p.GetOnDraw()(p)
+		} else {
+			p.Draw()
+
+			for _, child := range p.Children() {
+				child.HandleMessage(base.BuildDrawMessage(child.Handler()))
+			}
+		}
+	case base.WmClosePopup:
+		if p.GetOnReceiveMessage() == nil || !p.GetOnReceiveMessage()(p, msg) {
+			p.Close()
+		}
+	case base.WmLButtonDown, base.WmLButtonUp, base.WmLButtonDblClick, base.WmRButtonDown, base.WmRButtonUp:
+		// Click is for child under mouse.
+		ev := msg.Value.(*tcell.EventMouse)
+		x, y := ev.Position()
+
+		if v := base.ViewAt(p, x, y); v != nil && v.Handler() != p.Handler() {
+			v.HandleMessage(base.BuildClickMouseMessage(v.Handler(), ev, msg.Type))
+		} else {
+			msg.Handler = p.Handler()
+			p.View.HandleMessage(msg)
+		}
+	default:
+		msg.Handler = p.Handler()
+		p.View.HandleMessage(msg)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewPopup create new closed popup with shadow. Use base.RootCanvas to draw
+// outside of owner.
+func NewPopup(name string, message base.Bus, parentCanvas base.TCanvas) Popup {
+	p := Popup{
+		Shadow: true,
+		View:   base.NewView(name, message, parentCanvas),
+	}
+
+	p.SetEnabled(true)
+	p.SetVisible(true)
+
+	return p
+}
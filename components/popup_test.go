@@ -0,0 +1,106 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func TestPopup_open_close(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	p := NewPopup("popup", appConfig.Message, base.NewMemoryCanvas(10, 5))
+	p.SetBounds(base.Rect{X: 1, Y: 1, Width: 4, Height: 2})
+
+	closed := 0
+	p.OnClose = func(*Popup) {
+		closed++
+	}
+
+	p.Close()
+
+	if closed != 0 || len(pendingMessages(appConfig, base.WmDestroy)) != 0 {
+		t.Error("Closed popup can't be closed")
+	}
+
+	p.Open()
+	p.Open()
+
+	if msgs := pendingMessages(appConfig, base.WmCreate); len(msgs) != 1 || msgs[0].Value != &p {
+		t.Errorf("Popup must be created once. Found %v", msgs)
+	}
+
+	if !p.GetOpened() {
+		t.Error("Popup must be opened")
+	}
+
+	p.HandleMessage(base.BuildClosePopupMessage(p.Handler()))
+
+	if msgs := pendingMessages(appConfig, base.WmDestroy); len(msgs) != 1 || msgs[0].Value != &p {
+		t.Errorf("Popup must be destroyed. Found %v", msgs)
+	}
+
+	if p.GetOpened() || closed != 1 {
+		t.Error("Popup must be closed and OnClose called")
+	}
+}
+
+func TestPopup_draw_shadow(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 5)
+
+	p := NewPopup("popup", appConfig.Message, m)
+	p.SetBounds(base.Rect{X: 1, Y: 1, Width: 4, Height: 2})
+	p.HandleMessage(base.BuildDrawMessage(p.Handler()))
+
+	if m.GetCell(5, 2).Style == m.GetCell(0, 0).Style || m.GetCell(2, 3).Style == m.GetCell(0, 0).Style {
+		t.Error("Popup must cast a shadow")
+	}
+
+	if m.GetCell(5, 1).Style != m.GetCell(0, 0).Style {
+		t.Error("Shadow starts one line below popup")
+	}
+}
+
+func TestPopup_click_child(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	p := NewPopup("popup", appConfig.Message, base.NewMemoryCanvas(10, 5))
+	p.SetBounds(base.Rect{X: 1, Y: 1, Width: 4, Height: 2})
+
+	v := base.NewView("child", appConfig.Message, p.Canvas())
+	v.SetBounds(base.Rect{X: 0, Y: 1, Width: 4, Height: 1})
+	v.SetVisible(true)
+	v.SetParent(&p)
+	p.AddChild(&v)
+
+	clicked := false
+	v.SetOnReceiveMessage(func(_ base.TComponent, msg base.Message) bool {
+		clicked = clicked || msg.Type == base.WmLButtonDown
+
+		return false
+	})
+
+	ev := tcell.NewEventMouse(2, 2, tcell.Button1, tcell.ModNone)
+	p.HandleMessage(base.BuildClickMouseMessage(p.Handler(), ev, base.WmLButtonDown))
+
+	if !clicked {
+		t.Error("Click must be sent to child under mouse")
+	}
+}
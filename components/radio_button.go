@@ -0,0 +1,191 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// OnRadioButtonChange is call when radio button is checked or unchecked.
+type OnRadioButtonChange func(*RadioButton)
+
+// RadioButton is a button with caption. When checked, other radio buttons
+// with same parent and group are unchecked.
+type RadioButton struct {
+	// Radio buttons of same parent and same group are mutually exclusive.
+	Group int
+	// Call when radio button is checked or unchecked.
+	OnChange OnRadioButtonChange
+
+	caption  string
+	mnemonic mnemonic
+	checked  bool
+	pressed  bool
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (r *RadioButton) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case r.Handler():
+		r.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		r.manageMyMessage(msg)
+
+		for _, child := range r.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range r.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetCaption change text. Caption can have a mnemonic (e.g. "&Left").
+func (r *RadioButton) SetCaption(caption string) {
+	r.caption = caption
+	r.mnemonic = parseMnemonic(caption)
+	r.Invalidate()
+}
+
+// GetCaption return text with mnemonic mark.
+func (r *RadioButton) GetCaption() string {
+	return r.caption
+}
+
+// SetChecked check or uncheck radio button. Checked radio button uncheck
+// others of its group.
+func (r *RadioButton) SetChecked(checked bool) {
+	if checked == r.checked {
+		return
+	}
+
+	if checked && r.GetParent() != nil {
+		for _, c := range r.GetParent().Children() {
+			if other, ok := c.(*RadioButton); ok && other != r && other.Group == r.Group {
+				other.SetChecked(false)
+			}
+		}
+	}
+
+	r.checked = checked
+	r.Invalidate()
+
+	if r.OnChange != nil {
+		r.OnChange(r)
+	}
+}
+
+// GetChecked return true if radio button is checked.
+func (r *RadioButton) GetChecked() bool {
+	return r.checked
+}
+
+// Draw the radio button.
+func (r *RadioButton) Draw() {
+	if !r.GetVisible() {
+		return
+	}
+
+	mark := ' '
+
+	if r.checked {
+		mark = RadioButtonChecked
+	}
+
+	drawClusterItem(r.Canvas(), 0, r.GetBounds().Width, "("+string(mark)+") ", r.mnemonic,
+		clusterStyle(r, r.GetFocused()))
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (r *RadioButton) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp:
+		if r.GetOnReceiveMessage() != nil && r.GetOnReceiveMessage()(r, msg) {
+			return
+		}
+
+		r.manageRadioButtonMessage(msg)
+	case base.WmMouseLeave:
+		r.pressed = false
+		fallthrough
+	default:
+		msg.Handler = r.Handler()
+		r.View.HandleMessage(msg)
+	}
+}
+
+func (r *RadioButton) manageRadioButtonMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if r.GetOnDraw() != nil {
+			r.GetOnDraw()(r)
+		} else {
+			r.Draw()
+		}
+
+		return
+	}
+
+	if !r.GetEnabled() || !r.GetVisible() {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		ev := msg.Value.(*tcell.EventKey)
+
+		if r.mnemonic.match(ev) {
+			base.SetFocus(r)
+			r.SetChecked(true)
+		} else if r.GetFocused() && isSpaceKey(ev) {
+			r.SetChecked(true)
+		}
+	case base.WmLButtonDown:
+		r.pressed = true
+		base.SetFocus(r)
+	case base.WmLButtonUp:
+		if r.pressed {
+			r.pressed = false
+			r.SetChecked(true)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewRadioButton create new unchecked radio button.
+func NewRadioButton(name string, message base.Bus, parentCanvas base.TCanvas) RadioButton {
+	r := RadioButton{
+		View:     base.NewView(name, message, parentCanvas),
+		mnemonic: parseMnemonic(""),
+	}
+
+	r.SetEnabled(true)
+	r.SetVisible(true)
+
+	return r
+}
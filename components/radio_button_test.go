@@ -0,0 +1,88 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func TestRadioButton_exclusive(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	parent := base.NewView("parent", appConfig.Message, base.NewMemoryCanvas(20, 10))
+
+	radios := make([]*RadioButton, 3)
+	changes := 0
+
+	for i := range radios {
+		r := NewRadioButton("radio", appConfig.Message, parent.ClientCanvas())
+		r.SetBounds(base.Rect{X: 0, Y: i, Width: 10, Height: 1})
+		r.SetParent(&parent)
+		r.OnChange = func(*RadioButton) {
+			changes++
+		}
+		parent.AddChild(&r)
+
+		radios[i] = &r
+	}
+
+	// Other group.
+	radios[2].Group = 1
+	radios[2].SetChecked(true)
+
+	radios[0].SetChecked(true)
+	radios[1].SetChecked(true)
+
+	if radios[0].GetChecked() || !radios[1].GetChecked() || !radios[2].GetChecked() {
+		t.Error("Only one radio button of group can be checked")
+	}
+
+	// 2 + check 0 + uncheck 0, check 1.
+	if changes != 4 {
+		t.Errorf("OnChange must be call 4 times. Found %d", changes)
+	}
+
+	m := base.NewMemoryCanvas(10, 1)
+
+	r := NewRadioButton("radio", appConfig.Message, m)
+	r.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 1})
+	r.SetCaption("&Left")
+	r.SetChecked(true)
+	r.HandleMessage(base.BuildDrawMessage(r.Handler()))
+
+	if l := memoryCanvasLine(m, 0); l != "(•) Left  " {
+		t.Errorf("Wrong radio button. Found '%s'", l)
+	}
+}
+
+func TestRadioButton_keys(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	r := NewRadioButton("radio", appConfig.Message, base.NewMemoryCanvas(10, 1))
+	r.SetCaption("&Left")
+
+	sendKey(&r, tcell.KeyRune, 'l', tcell.ModAlt)
+
+	if !r.GetChecked() {
+		t.Error("Alt+mnemonic must check radio button")
+	}
+
+	if msg := <-*appConfig.Message.Channel(); msg.Type != base.WmActivate || msg.Handler != r.Handler() {
+		t.Errorf("Alt+mnemonic must focus radio button. Found %+v", msg)
+	}
+}
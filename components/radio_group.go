@@ -0,0 +1,241 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// OnRadioGroupChange is call when selected item of radio group change.
+type OnRadioGroupChange func(*RadioGroup)
+
+// RadioGroup display items as radio buttons, one per line. Only one item can
+// be selected. Arrow keys move selection, items can have mnemonic.
+type RadioGroup struct {
+	// Call when selected item change.
+	OnChange OnRadioGroupChange
+
+	items     []string
+	mnemonics []mnemonic
+	itemIndex int
+	// Item under mouse when button is down, -1 if none.
+	pressed int
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (g *RadioGroup) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case g.Handler():
+		g.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		g.manageMyMessage(msg)
+
+		for _, child := range g.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range g.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetItems change items. Selection is removed if out of items.
+func (g *RadioGroup) SetItems(items []string) {
+	g.items = items
+	g.mnemonics = make([]mnemonic, len(items))
+
+	for i, item := range items {
+		g.mnemonics[i] = parseMnemonic(item)
+	}
+
+	if g.itemIndex >= len(items) {
+		g.SetItemIndex(-1)
+	}
+
+	g.Invalidate()
+}
+
+// GetItems return items with mnemonic mark.
+func (g *RadioGroup) GetItems() []string {
+	return g.items
+}
+
+// SetItemIndex select item (-1 for none). OnChange is call if selection
+// change. Index out of items is ignored.
+func (g *RadioGroup) SetItemIndex(i int) {
+	if i == g.itemIndex || i < -1 || i >= len(g.items) {
+		return
+	}
+
+	g.itemIndex = i
+	g.Invalidate()
+
+	if g.OnChange != nil {
+		g.OnChange(g)
+	}
+}
+
+// GetItemIndex return selected item or -1.
+func (g *RadioGroup) GetItemIndex() int {
+	return g.itemIndex
+}
+
+// Draw the radio group.
+func (g *RadioGroup) Draw() {
+	if !g.GetVisible() {
+		return
+	}
+
+	canvas := g.Canvas()
+	bounds := g.GetBounds()
+
+	canvas.SetBrush(clusterStyle(g, false))
+	canvas.Fill(base.Rect{X: 0, Y: 0, Width: bounds.Width, Height: bounds.Height})
+
+	current := base.MaxInt(g.itemIndex, 0)
+
+	for i, m := range g.mnemonics {
+		mark := ' '
+
+		if i == g.itemIndex {
+			mark = RadioButtonChecked
+		}
+
+		drawClusterItem(canvas, i, bounds.Width, "("+string(mark)+") ", m,
+			clusterStyle(g, g.GetFocused() && i == current))
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (g *RadioGroup) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmKey, base.WmLButtonDown, base.WmLButtonUp:
+		if g.GetOnReceiveMessage() != nil && g.GetOnReceiveMessage()(g, msg) {
+			return
+		}
+
+		g.manageRadioGroupMessage(msg)
+	case base.WmMouseLeave:
+		g.pressed = -1
+		fallthrough
+	default:
+		msg.Handler = g.Handler()
+		g.View.HandleMessage(msg)
+	}
+}
+
+func (g *RadioGroup) manageRadioGroupMessage(msg base.Message) {
+	if msg.Type == base.WmDraw {
+		if g.GetOnDraw() != nil {
+			g.GetOnDraw()(g)
+		} else {
+			g.Draw()
+		}
+
+		return
+	}
+
+	if !g.GetEnabled() || !g.GetVisible() || len(g.items) == 0 {
+		return
+	}
+
+	switch msg.Type {
+	case base.WmKey:
+		g.manageKey(msg.Value.(*tcell.EventKey))
+	case base.WmLButtonDown:
+		g.pressed = g.itemAt(msg.Value.(*tcell.EventMouse))
+		base.SetFocus(g)
+	case base.WmLButtonUp:
+		if i := g.itemAt(msg.Value.(*tcell.EventMouse)); i >= 0 && i == g.pressed {
+			g.SetItemIndex(i)
+		}
+
+		g.pressed = -1
+	}
+}
+
+func (g *RadioGroup) manageKey(ev *tcell.EventKey) {
+	for i, m := range g.mnemonics {
+		if m.match(ev) {
+			base.SetFocus(g)
+			g.SetItemIndex(i)
+
+			return
+		}
+	}
+
+	if !g.GetFocused() {
+		return
+	}
+
+	count := len(g.items)
+
+	switch ev.Key() {
+	case tcell.KeyUp, tcell.KeyLeft:
+		if g.itemIndex <= 0 {
+			g.SetItemIndex(count - 1)
+		} else {
+			g.SetItemIndex(g.itemIndex - 1)
+		}
+	case tcell.KeyDown, tcell.KeyRight:
+		g.SetItemIndex((g.itemIndex + 1) % count)
+	case tcell.KeyRune:
+		if isSpaceKey(ev) {
+			g.SetItemIndex(base.MaxInt(g.itemIndex, 0))
+		}
+	}
+}
+
+// Return item at mouse position or -1.
+func (g *RadioGroup) itemAt(ev *tcell.EventMouse) int {
+	_, y := ev.Position()
+
+	i := y - base.AbsoluteBounds(g).Y
+
+	if i < 0 || i >= len(g.items) {
+		return -1
+	}
+
+	return i
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewRadioGroup create new radio group without item.
+func NewRadioGroup(name string, message base.Bus, parentCanvas base.TCanvas) RadioGroup {
+	g := RadioGroup{
+		View:      base.NewView(name, message, parentCanvas),
+		itemIndex: -1,
+		pressed:   -1,
+	}
+
+	g.SetEnabled(true)
+	g.SetVisible(true)
+
+	return g
+}
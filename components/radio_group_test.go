@@ -0,0 +1,120 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func createTestRadioGroup(appConfig base.ApplicationConfig, m *base.MemoryCanvas) (*RadioGroup, *[]int) {
+	g := NewRadioGroup("group", appConfig.Message, m)
+	g.SetBounds(base.Rect{X: 0, Y: 1, Width: 10, Height: 3})
+	g.SetItems([]string{"&Left", "&Center", "&Right"})
+
+	changes := []int{}
+
+	g.OnChange = func(g *RadioGroup) {
+		changes = append(changes, g.GetItemIndex())
+	}
+
+	return &g, &changes
+}
+
+func TestRadioGroup_Draw(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 4)
+
+	g, _ := createTestRadioGroup(appConfig, m)
+	g.SetItemIndex(1)
+	g.SetFocused(true)
+
+	g.HandleMessage(base.BuildDrawMessage(g.Handler()))
+
+	for y, line := range []string{"( ) Left  ", "(•) Center", "( ) Right "} {
+		if l := memoryCanvasLine(m, y+1); l != line {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, line, l)
+		}
+	}
+
+	if m.GetCell(9, 2).Style != g.GetStyle(base.RoleClusterFocused) || m.GetCell(9, 1).Style != g.GetStyle(base.RoleCluster) {
+		t.Error("Only selected item of focused group must use focused style")
+	}
+}
+
+func TestRadioGroup_keys(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	g, changes := createTestRadioGroup(appConfig, base.NewMemoryCanvas(10, 4))
+
+	sendKey(g, tcell.KeyDown, 0, tcell.ModNone)
+
+	if g.GetItemIndex() != -1 {
+		t.Error("Arrow must not move selection without focus")
+	}
+
+	sendKey(g, tcell.KeyRune, 'r', tcell.ModAlt)
+
+	g.SetFocused(true)
+
+	sendKey(g, tcell.KeyDown, 0, tcell.ModNone)
+	sendKey(g, tcell.KeyUp, 0, tcell.ModNone)
+	sendKey(g, tcell.KeyLeft, 0, tcell.ModNone)
+
+	want := []int{2, 0, 2, 1}
+
+	if len(*changes) != len(want) {
+		t.Fatalf("Selection must be %v. Found %v", want, *changes)
+	}
+
+	for i := range want {
+		if (*changes)[i] != want[i] {
+			t.Errorf("Selection must be %v. Found %v", want, *changes)
+		}
+	}
+}
+
+func TestRadioGroup_mouse(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	g, _ := createTestRadioGroup(appConfig, base.NewMemoryCanvas(10, 4))
+
+	click := func(side uint, y int) {
+		g.HandleMessage(base.BuildClickMouseMessage(g.Handler(), tcell.NewEventMouse(5, y, tcell.ButtonNone, tcell.ModNone), side))
+	}
+
+	click(base.WmLButtonDown, 3)
+	click(base.WmLButtonUp, 3)
+
+	if g.GetItemIndex() != 2 {
+		t.Errorf("Click must select item 2. Found %d", g.GetItemIndex())
+	}
+
+	click(base.WmLButtonDown, 1)
+	click(base.WmLButtonUp, 2)
+
+	if g.GetItemIndex() != 2 {
+		t.Error("Mouse released on other item must not change selection")
+	}
+
+	g.SetItems([]string{"One"})
+
+	if g.GetItemIndex() != -1 {
+		t.Error("Selection out of items must be removed")
+	}
+}
@@ -0,0 +1,316 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+	"github.com/google/uuid"
+)
+
+// DefaultWheelStep is number of lines scrolled by mouse wheel.
+const DefaultWheelStep = 3
+
+// ScrollView is a view with content larger than its bounds. Children bounds
+// are in content coordinates, only visible part of content is draw.
+type ScrollView struct {
+	// Number of lines or columns scrolled by mouse wheel.
+	WheelStep int
+
+	content       *base.Canvas
+	contentWidth  int
+	contentHeight int
+
+	base.View
+}
+
+// HandleMessage is use to manage message.
+func (s *ScrollView) HandleMessage(msg base.Message) bool {
+	switch msg.Handler {
+	case s.Handler():
+		s.manageMyMessage(msg)
+		return true
+	case base.BroadcastHandler():
+		s.manageMyMessage(msg)
+
+		for _, child := range s.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range s.Children() {
+			if child.HandleMessage(msg) {
+				s.manageChildMessage(msg)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SetBounds set viewport size. Scroll position is kept if possible.
+func (s *ScrollView) SetBounds(r base.Rect) {
+	s.View.SetBounds(r)
+
+	x, y := s.GetScroll()
+
+	// Origin is reset by new bounds.
+	s.content.UpdateBounds(base.Rect{X: 0, Y: 0, Width: r.Width, Height: r.Height})
+	s.SetScroll(x, y)
+}
+
+// GetClientBounds return content area. X and Y are negative when view is
+// scrolled.
+func (s *ScrollView) GetClientBounds() base.Rect {
+	x, y := s.GetScroll()
+	width, height := s.GetContentSize()
+
+	return base.Rect{
+		X:      -x,
+		Y:      -y,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// ClientCanvas return canvas of content. Children must be created on it.
+func (s *ScrollView) ClientCanvas() base.TCanvas {
+	return s.content
+}
+
+// SetContentSize set size of content. Content is never smaller than area
+// used by children.
+func (s *ScrollView) SetContentSize(width, height int) {
+	s.contentWidth = width
+	s.contentHeight = height
+
+	x, y := s.GetScroll()
+	s.SetScroll(x, y)
+}
+
+// GetContentSize return size of content.
+func (s *ScrollView) GetContentSize() (int, int) {
+	width := s.contentWidth
+	height := s.contentHeight
+
+	for _, c := range s.Children() {
+		if child, ok := c.(base.TView); ok {
+			bounds := child.GetBounds()
+
+			width = base.MaxInt(width, bounds.X+bounds.Width)
+			height = base.MaxInt(height, bounds.Y+bounds.Height)
+		}
+	}
+
+	return width, height
+}
+
+// GetScroll return position of content at top-left corner of view.
+func (s *ScrollView) GetScroll() (int, int) {
+	return s.content.GetOrigin()
+}
+
+// SetScroll move content. Position is clamped to keep viewport in content.
+func (s *ScrollView) SetScroll(x, y int) {
+	bounds := s.GetBounds()
+	width, height := s.GetContentSize()
+
+	x = base.MinInt(x, width-bounds.Width)
+	y = base.MinInt(y, height-bounds.Height)
+	x = base.MaxInt(x, 0)
+	y = base.MaxInt(y, 0)
+
+	if oldX, oldY := s.GetScroll(); oldX == x && oldY == y {
+		return
+	}
+
+	s.content.SetOrigin(x, y)
+	s.Invalidate()
+}
+
+// ScrollBy move content by (dx, dy). Return false if content cannot move.
+func (s *ScrollView) ScrollBy(dx, dy int) bool {
+	x, y := s.GetScroll()
+
+	s.SetScroll(x+dx, y+dy)
+
+	newX, newY := s.GetScroll()
+
+	return newX != x || newY != y
+}
+
+// ScrollToVisible scroll minimum to show area `r` of content.
+func (s *ScrollView) ScrollToVisible(r base.Rect) {
+	bounds := s.GetBounds()
+	x, y := s.GetScroll()
+
+	if r.X+r.Width > x+bounds.Width {
+		x = r.X + r.Width - bounds.Width
+	}
+
+	if r.X < x {
+		x = r.X
+	}
+
+	if r.Y+r.Height > y+bounds.Height {
+		y = r.Y + r.Height - bounds.Height
+	}
+
+	if r.Y < y {
+		y = r.Y
+	}
+
+	s.SetScroll(x, y)
+}
+
+// EnsureVisible scroll to show child or grand child `v`.
+func (s *ScrollView) EnsureVisible(v base.TView) {
+	r := base.AbsoluteBounds(v)
+	origin := base.AbsoluteBounds(s)
+	client := s.GetClientBounds()
+
+	r.X -= origin.X + client.X
+	r.Y -= origin.Y + client.Y
+
+	s.ScrollToVisible(r)
+}
+
+// Draw the view.
+func (s *ScrollView) Draw() {
+	if !s.GetVisible() {
+		return
+	}
+
+	canvas := s.Canvas()
+
+	canvas.SetBrush(s.GetStyleOf(s, base.RoleView))
+
+	bounds := s.GetBounds()
+	bounds.X = 0
+	bounds.Y = 0
+
+	canvas.Fill(bounds)
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (s *ScrollView) manageMyMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw, base.WmChangeBounds, base.WmMouseWheel, base.WmScroll:
+		if s.GetOnReceiveMessage() != nil && s.GetOnReceiveMessage()(s, msg) {
+			return
+		}
+
+		s.manageScrollMessage(msg)
+	default:
+		msg.Handler = s.Handler()
+		s.View.HandleMessage(msg)
+	}
+}
+
+func (s *ScrollView) manageScrollMessage(msg base.Message) {
+	switch msg.Type {
+	case base.WmDraw:
+		if s.GetOnDraw() != nil {
+			s.GetOnDraw()(s)
+		} else {
+			s.Draw()
+			// Redraw children.
+			for _, child := range s.Children() {
+				child.HandleMessage(base.BuildDrawMessage(child.Handler()))
+			}
+		}
+	case base.WmChangeBounds:
+		if s.GetOnChangeBounds() != nil {
+			s.GetOnChangeBounds()(msg.Value.(base.Rect))
+		}
+
+		// Repaint old area and new area.
+		s.Invalidate()
+		s.SetBounds(msg.Value.(base.Rect))
+		s.Invalidate()
+	case base.WmMouseWheel:
+		ev := msg.Value.(*tcell.EventMouse)
+
+		if !s.scrollWheel(ev.Buttons()) {
+			base.SendWheelToParent(s, ev)
+		}
+	case base.WmScroll:
+		r := msg.Value.(base.Rect)
+
+		s.SetScroll(r.X, r.Y)
+	}
+}
+
+// Return true if content move.
+func (s *ScrollView) scrollWheel(buttons tcell.ButtonMask) bool {
+	dx, dy := 0, 0
+
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		dy = -s.WheelStep
+	case buttons&tcell.WheelDown != 0:
+		dy = s.WheelStep
+	case buttons&tcell.WheelLeft != 0:
+		dx = -s.WheelStep
+	case buttons&tcell.WheelRight != 0:
+		dx = s.WheelStep
+	}
+
+	return s.ScrollBy(dx, dy)
+}
+
+// Child handle message. Focused child must be visible.
+func (s *ScrollView) manageChildMessage(msg base.Message) {
+	if msg.Type != base.WmActivate || msg.Value != base.WaActive {
+		return
+	}
+
+	if v := findViewByHandler(s, msg.Handler); v != nil {
+		s.EnsureVisible(v)
+	}
+}
+
+// Return descendant view of `c` with handler.
+func findViewByHandler(c base.TComponent, handler uuid.UUID) base.TView {
+	for _, child := range c.Children() {
+		if v, ok := child.(base.TView); ok && v.Handler() == handler {
+			return v
+		}
+
+		if v := findViewByHandler(child, handler); v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewScrollView create new scroll view.
+func NewScrollView(name string, message base.Bus, parentCanvas base.TCanvas) ScrollView {
+	s := ScrollView{
+		WheelStep: DefaultWheelStep,
+		View:      base.NewView(name, message, parentCanvas),
+	}
+
+	s.content = base.NewScrollCanvas(s.Canvas(), base.Rect{X: 0, Y: 0, Width: 0, Height: 0})
+
+	return s
+}
@@ -0,0 +1,190 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Scroll view at (1, 1) 4x2 with content 20x10 and a child at (5, 3) 2x1.
+func createTestScrollView(appConfig base.ApplicationConfig, m *base.MemoryCanvas) (*ScrollView, *base.View) {
+	s := NewScrollView("scroll", appConfig.Message, m)
+	s.SetBounds(base.Rect{X: 1, Y: 1, Width: 4, Height: 2})
+	s.SetVisible(true)
+	s.SetContentSize(20, 10)
+
+	child := base.NewView("child", appConfig.Message, s.ClientCanvas())
+	child.SetBounds(base.Rect{X: 5, Y: 3, Width: 2, Height: 1})
+	child.SetVisible(true)
+	child.SetBackgroundColor(tcell.ColorRed)
+	child.SetParent(&s)
+	s.AddChild(&child)
+
+	return &s, &child
+}
+
+// Return a line of background: 'x' for red cell, '.' else.
+func backgroundLine(m *base.MemoryCanvas, y int) string {
+	width, _ := m.Size()
+	line := make([]rune, 0, width)
+
+	for x := 0; x < width; x++ {
+		if _, bg, _ := m.GetCell(x, y).Style.Decompose(); bg == tcell.ColorRed {
+			line = append(line, 'x')
+		} else {
+			line = append(line, '.')
+		}
+	}
+
+	return string(line)
+}
+
+func TestScrollView_Draw_scrolled_and_clipped(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	tests := []struct {
+		x, y  int
+		lines []string
+	}{
+		{0, 0, []string{"......", "......", "......", "......"}},
+		{4, 3, []string{"......", "..xx..", "......", "......"}},
+		{6, 2, []string{"......", "......", ".x....", "......"}},
+	}
+
+	for _, test := range tests {
+		m := base.NewMemoryCanvas(6, 4)
+		s, _ := createTestScrollView(appConfig, m)
+
+		s.SetScroll(test.x, test.y)
+		s.HandleMessage(base.BuildDrawMessage(s.Handler()))
+
+		for y, line := range test.lines {
+			if l := backgroundLine(m, y); l != line {
+				t.Errorf("Scroll (%d, %d), line %d must be '%s'. Found '%s'", test.x, test.y, y, line, l)
+			}
+		}
+	}
+}
+
+func TestScrollView_SetScroll_clamp(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	s, child := createTestScrollView(appConfig, base.NewMemoryCanvas(6, 4))
+
+	s.SetScroll(-5, 100)
+
+	if x, y := s.GetScroll(); x != 0 || y != 8 {
+		t.Errorf("Scroll must be clamped to (0, 8). Found (%d, %d)", x, y)
+	}
+
+	child.SetBounds(base.Rect{X: 25, Y: 0, Width: 5, Height: 1})
+
+	if w, h := s.GetContentSize(); w != 30 || h != 10 {
+		t.Errorf("Content must grow with children. Found %dx%d", w, h)
+	}
+
+	if s.ScrollBy(0, 1) {
+		t.Error("Content cannot move at bottom")
+	}
+
+	if !s.ScrollBy(26, 0) {
+		t.Error("Content must move")
+	}
+
+	if x, _ := s.GetScroll(); x != 26 {
+		t.Errorf("Scroll must be 26. Found %d", x)
+	}
+
+	if r := base.AbsoluteBounds(child); r.X != 0 || r.Y != -7 {
+		t.Errorf("Absolute bounds of child must be scrolled. Found %+v", r)
+	}
+}
+
+func TestScrollView_messages(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	parent := base.NewView("parent", appConfig.Message, base.NewMemoryCanvas(6, 4))
+
+	s, _ := createTestScrollView(appConfig, base.NewMemoryCanvas(6, 4))
+	s.SetParent(&parent)
+
+	s.HandleMessage(base.BuildScrollMessage(s.Handler(), 2, 5))
+
+	if x, y := s.GetScroll(); x != 2 || y != 5 {
+		t.Errorf("Scroll message must move content. Found (%d, %d)", x, y)
+	}
+
+	wheel := func(buttons tcell.ButtonMask) {
+		s.HandleMessage(base.BuildMouseWheelMessage(s.Handler(), tcell.NewEventMouse(0, 0, buttons, tcell.ModNone)))
+	}
+
+	wheel(tcell.WheelUp)
+	wheel(tcell.WheelRight)
+
+	if x, y := s.GetScroll(); x != 5 || y != 2 {
+		t.Errorf("Wheel must move content. Found (%d, %d)", x, y)
+	}
+
+	// Drop invalidate messages.
+	for len(*appConfig.Message.Channel()) > 0 {
+		<-*appConfig.Message.Channel()
+	}
+
+	wheel(tcell.WheelUp)
+	wheel(tcell.WheelUp)
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != base.WmInvalidate {
+		t.Errorf("View must be repaint. Found %+v", msg)
+	}
+
+	msg = <-*appConfig.Message.Channel()
+
+	if msg.Type != base.WmMouseWheel || msg.Handler != parent.Handler() {
+		t.Errorf("Wheel must be sent to parent at top. Found %+v", msg)
+	}
+}
+
+func TestScrollView_focused_child_visible(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	s, child := createTestScrollView(appConfig, base.NewMemoryCanvas(6, 4))
+
+	grandChild := base.NewView("grand child", appConfig.Message, child.ClientCanvas())
+	grandChild.SetBounds(base.Rect{X: 1, Y: 0, Width: 1, Height: 1})
+	grandChild.SetParent(child)
+	child.AddChild(&grandChild)
+
+	s.HandleMessage(base.BuildActivateMessage(child.Handler()))
+
+	if x, y := s.GetScroll(); x != 3 || y != 2 {
+		t.Errorf("Focused child must be visible. Found (%d, %d)", x, y)
+	}
+
+	if !child.GetFocused() {
+		t.Error("Child must receive message")
+	}
+
+	s.SetScroll(10, 8)
+	s.HandleMessage(base.BuildActivateMessage(grandChild.Handler()))
+
+	if x, y := s.GetScroll(); x != 6 || y != 3 {
+		t.Errorf("Focused grand child must be visible. Found (%d, %d)", x, y)
+	}
+}
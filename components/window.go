@@ -27,32 +27,103 @@ const (
 	BorderTypeDouble = 1
 	// BorderTypeEmpty border without line.
 	BorderTypeEmpty = 2
-
-	// Index for draw windows border.
+	// BorderTypeHeavy border with heavy line.
+	BorderTypeHeavy = 3
+	// BorderTypeRounded border with single line and rounded corners.
+	BorderTypeRounded = 4
+	// BorderTypeASCII border with ASCII characters.
+	BorderTypeASCII = 5
+
+	// Characters for draw title bar.
+
+	// CloseLeftChar close character left.
+	CloseLeftChar = '['
+	// CloseRightChar close character right.
+	CloseRightChar = ']'
+	// CloseChar close character.
+	CloseChar = '■'
+	// CaptionSpaceChar space before/after caption in title bar.
+	CaptionSpaceChar = ' '
+
+	// Index for draw windows border with DefaultDraw... functions.
 
 	// ULCorner upper left corner.
+	//
+	// Deprecated: use TCanvas.DrawRect.
 	ULCorner = 0
 	// HLine horizontal line.
+	//
+	// Deprecated: use TCanvas.DrawHLine.
 	HLine = 1
 	// CloseLeft close character left.
+	//
+	// Deprecated: use CloseLeftChar.
 	CloseLeft = 2
 	// CloseRight close character right.
+	//
+	// Deprecated: use CloseRightChar.
 	CloseRight = 3
 	// Close close character.
+	//
+	// Deprecated: use CloseChar.
 	Close = 4
 	// URCorner upper right corner.
+	//
+	// Deprecated: use TCanvas.DrawRect.
 	URCorner = 5
 	// CaptionSpace space before/after caption in title bar.
+	//
+	// Deprecated: use CaptionSpaceChar.
 	CaptionSpace = 6
 	// LLCorner lower left corner.
+	//
+	// Deprecated: use TCanvas.DrawRect.
 	LLCorner = 7
 	// LRCorner lower right corner.
+	//
+	// Deprecated: use TCanvas.DrawRect.
 	LRCorner = 8
 	// VLine vertical line.
+	//
+	// Deprecated: use TCanvas.DrawVLine.
 	VLine = 9
 )
 
-var bordersChars [][]rune
+// Minimum width of title bar to draw close button.
+const minimumTitleBar = 7
+
+// Line style of each border type.
+var borderLineStyles = map[BorderType]base.LineStyle{
+	BorderTypeSingle:  base.LineStyleSingle,
+	BorderTypeDouble:  base.LineStyleDouble,
+	BorderTypeEmpty:   base.LineStyleBlank,
+	BorderTypeHeavy:   base.LineStyleHeavy,
+	BorderTypeRounded: base.LineStyleRounded,
+	BorderTypeASCII:   base.LineStyleASCII,
+}
+
+// Characters of each border type, indexed by ULCorner... (see
+// DefaultDrawBottomBar).
+var bordersChars = map[BorderType][]rune{
+	BorderTypeSingle: {
+		ULCorner: tcell.RuneULCorner, HLine: tcell.RuneHLine,
+		CloseLeft: CloseLeftChar, CloseRight: CloseRightChar, Close: CloseChar,
+		URCorner: tcell.RuneURCorner, CaptionSpace: CaptionSpaceChar,
+		LLCorner: tcell.RuneLLCorner, LRCorner: tcell.RuneLRCorner, VLine: tcell.RuneVLine,
+	},
+	BorderTypeDouble: {
+		ULCorner: '╔', HLine: '═',
+		CloseLeft: CloseLeftChar, CloseRight: CloseRightChar, Close: CloseChar,
+		URCorner: '╗', CaptionSpace: CaptionSpaceChar,
+		LLCorner: '╚', LRCorner: '╝', VLine: '║',
+	},
+	BorderTypeEmpty: {
+		ULCorner: ' ', HLine: ' ',
+		CloseLeft: CloseLeftChar, CloseRight: CloseRightChar, Close: CloseChar,
+		URCorner: ' ', CaptionSpace: CaptionSpaceChar,
+		LLCorner: ' ', LRCorner: ' ', VLine: ' ',
+	},
+}
 
 // BorderType border of window.
 type BorderType int
@@ -61,10 +132,16 @@ type BorderType int
 type WindowBorder struct {
 	// Border type.
 	Type BorderType
-	// Border color.
+	// Use border colors instead of theme, even ColorDefault.
+	CustomColors bool
+	// Border color. If not ColorDefault, replaces color of theme.
 	BackgroundColor tcell.Color
-	// Border color.
+	// Border color. If not ColorDefault, replaces color of theme.
 	ForegroundColor tcell.Color
+	// Use border font instead of theme.
+	CustomFont bool
+	// Border text attributes.
+	Font base.Font
 }
 
 // Window is the base object of all widget.
@@ -73,55 +150,16 @@ type Window struct {
 	Caption string
 	// Border
 	Border WindowBorder
+	// Cast a shadow one cell right and one row down.
+	Shadow bool
+	// Call when modal result is set. If nil, window is destroyed.
+	OnModalResult OnModalResult
 
-	view base.View
-}
-
-func init() {
-	bordersChars = make([][]rune, 3)
-
-	borderTypeSingle := make([]rune, 10)
-
-	borderTypeSingle[ULCorner] = tcell.RuneULCorner
-	borderTypeSingle[HLine] = tcell.RuneHLine
-	borderTypeSingle[CloseLeft] = '['
-	borderTypeSingle[CloseRight] = ']'
-	borderTypeSingle[Close] = '■'
-	borderTypeSingle[URCorner] = tcell.RuneURCorner
-	borderTypeSingle[CaptionSpace] = ' '
-	borderTypeSingle[LLCorner] = tcell.RuneLLCorner
-	borderTypeSingle[LRCorner] = tcell.RuneLRCorner
-	borderTypeSingle[VLine] = tcell.RuneVLine
-
-	borderTypeDouble := make([]rune, 10)
-
-	borderTypeDouble[ULCorner] = '╔'
-	borderTypeDouble[HLine] = '═'
-	borderTypeDouble[CloseLeft] = '['
-	borderTypeDouble[CloseRight] = ']'
-	borderTypeDouble[Close] = '■'
-	borderTypeDouble[URCorner] = '╗'
-	borderTypeDouble[CaptionSpace] = ' '
-	borderTypeDouble[LLCorner] = '╚'
-	borderTypeDouble[LRCorner] = '╝'
-	borderTypeDouble[VLine] = '║'
-
-	borderTypeEmpty := make([]rune, 10)
-
-	borderTypeEmpty[ULCorner] = ' '
-	borderTypeEmpty[HLine] = ' '
-	borderTypeEmpty[CloseLeft] = '['
-	borderTypeEmpty[CloseRight] = ']'
-	borderTypeEmpty[Close] = '■'
-	borderTypeEmpty[URCorner] = ' '
-	borderTypeEmpty[CaptionSpace] = ' '
-	borderTypeEmpty[LLCorner] = ' '
-	borderTypeEmpty[LRCorner] = ' '
-	borderTypeEmpty[VLine] = ' '
-
-	bordersChars[BorderTypeSingle] = borderTypeSingle
-	bordersChars[BorderTypeDouble] = borderTypeDouble
-	bordersChars[BorderTypeEmpty] = borderTypeEmpty
+	view          base.View
+	parentCanvas  base.TCanvas
+	defaultButton *Button
+	cancelButton  *Button
+	modalResult   ModalResult
 }
 
 //------------------------------------------------------------------------------
@@ -217,9 +255,19 @@ func (w *Window) HandleMessage(msg base.Message) bool {
 	case base.BroadcastHandler():
 		w.manageMyMessage(msg)
 
-		// Redraw children.
+		// Children are draw by manageMyMessage.
+		if msg.Type == base.WmDraw {
+			return false
+		}
+
+		for _, child := range w.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		// Maybe for a child.
 		for _, child := range w.Children() {
 			if child.HandleMessage(msg) {
+				return true
 			}
 		}
 	}
@@ -262,6 +310,10 @@ func (w *Window) GetFocused() bool {
 
 // SetVisible if component is visible.
 func (w *Window) SetVisible(s bool) {
+	if w.Shadow && w.GetVisible() != s {
+		w.Invalidate()
+	}
+
 	w.view.SetVisible(s)
 }
 
@@ -270,6 +322,16 @@ func (w *Window) GetVisible() bool {
 	return w.view.GetVisible()
 }
 
+// SetStayOnTop if window must stay above normal windows.
+func (w *Window) SetStayOnTop(s bool) {
+	w.view.SetStayOnTop(s)
+}
+
+// GetStayOnTop return true if window stay above normal windows.
+func (w *Window) GetStayOnTop() bool {
+	return w.view.GetStayOnTop()
+}
+
 // GetBackgroundColor return background color.
 func (w *Window) GetBackgroundColor() tcell.Color {
 	return w.view.GetBackgroundColor()
@@ -290,6 +352,23 @@ func (w *Window) SetForegroundColor(c tcell.Color) {
 	w.view.SetForegroundColor(c)
 }
 
+// Invalidate ask application to repaint window and its shadow at next frame.
+func (w *Window) Invalidate() {
+	if !w.Shadow {
+		w.view.Invalidate()
+
+		return
+	}
+
+	// Shadow is out of window bounds.
+	w.GetMessageBus().Send(base.BuildInvalidateMessage(base.ShadowBounds(base.AbsoluteBounds(w))))
+}
+
+// InvalidateRect ask application to repaint a part of window at next frame.
+func (w *Window) InvalidateRect(r base.Rect) {
+	w.view.InvalidateRect(r)
+}
+
 // Canvas of view.
 func (w *Window) Canvas() base.TCanvas {
 	return w.view.Canvas()
@@ -333,9 +412,7 @@ func (w *Window) Draw() {
 
 	canvas := w.Canvas()
 
-	canvas.SetBrush(tcell.StyleDefault.
-		Foreground(w.GetForegroundColor()).
-		Background(w.GetBackgroundColor()))
+	canvas.SetBrush(w.GetStyle(base.RoleWindowClient))
 
 	// Draw background of window
 	bounds := w.GetBounds()
@@ -344,90 +421,161 @@ func (w *Window) Draw() {
 
 	canvas.Fill(bounds)
 
-	drawTitle(canvas, w)
+	drawBorder(canvas, w)
 
-	drawBottom(canvas, w)
+	if w.Shadow {
+		base.DrawShadowWithBrush(w.parentCanvas, w.GetBounds(), w.GetTheme().Style(base.RoleShadow))
+	}
+}
 
-	drawBorderLeft(canvas, w)
+// GetFont return text attributes.
+func (w *Window) GetFont() base.Font {
+	return w.view.GetFont()
+}
 
-	drawBorderRight(canvas, w)
+// SetFont change text attributes.
+func (w *Window) SetFont(f base.Font) {
+	w.view.SetFont(f)
 }
 
-//------------------------------------------------------------------------------
-// Internal function.
+// SetTheme set theme of window and children without theme.
+func (w *Window) SetTheme(t *base.Theme) {
+	w.view.SetTheme(t)
+}
 
-func drawTitle(canvas base.TCanvas, w *Window) {
-	titleBounds := base.Rect{
-		X:      0,
-		Y:      0,
-		Width:  w.GetBounds().Width,
-		Height: 1,
-	}
+// GetTheme return theme of window, of his parent or default theme.
+func (w *Window) GetTheme() *base.Theme {
+	return w.view.GetTheme()
+}
 
-	borderStyle := tcell.StyleDefault.
-		Foreground(w.Border.ForegroundColor).
-		Background(w.Border.BackgroundColor)
+// GetStyle return style of role in theme. Colors set with SetForegroundColor
+// and SetBackgroundColor replace colors of theme.
+func (w *Window) GetStyle(role base.StyleRole) tcell.Style {
+	return w.view.GetStyleOf(w, role)
+}
 
-	canvas.SetBrush(borderStyle)
+// GetHover return true if mouse is over window.
+func (w *Window) GetHover() bool {
+	return w.view.GetHover()
+}
 
-	DefaultDrawTitleBar(canvas, titleBounds, w.Caption, bordersChars[w.Border.Type])
+// GetShadow return true if window cast a shadow.
+func (w *Window) GetShadow() bool {
+	return w.Shadow
 }
 
-func drawBottom(canvas base.TCanvas, w *Window) {
-	bounds := w.GetBounds()
+// SetDefaultButton set button clicked by Enter if focused control is not a
+// button.
+func (w *Window) SetDefaultButton(b *Button) {
+	w.defaultButton = b
+}
 
-	bottomBorderBounds := base.Rect{
-		X:      0,
-		Y:      bounds.Height - 1,
-		Width:  bounds.Width,
-		Height: 1,
-	}
+// GetDefaultButton return button clicked by Enter.
+func (w *Window) GetDefaultButton() *Button {
+	return w.defaultButton
+}
 
-	borderStyle := tcell.StyleDefault.
-		Foreground(w.Border.ForegroundColor).
-		Background(w.Border.BackgroundColor)
+// SetCancelButton set button clicked by Esc.
+func (w *Window) SetCancelButton(b *Button) {
+	w.cancelButton = b
+}
+
+// GetCancelButton return button clicked by Esc.
+func (w *Window) GetCancelButton() *Button {
+	return w.cancelButton
+}
+
+// SetModalResult set result of dialog and close it (see OnModalResult).
+// ModalResultNone does nothing.
+func (w *Window) SetModalResult(r ModalResult) {
+	w.modalResult = r
 
-	canvas.SetBrush(borderStyle)
+	if r == ModalResultNone {
+		return
+	}
+
+	if w.OnModalResult != nil {
+		w.OnModalResult(w, r)
+	} else {
+		w.GetMessageBus().Send(BuildDestroyWindowMessage(w))
+	}
+}
 
-	DefaultDrawBottomBar(canvas, bottomBorderBounds, bordersChars[w.Border.Type])
+// GetModalResult return result of dialog.
+func (w *Window) GetModalResult() ModalResult {
+	return w.modalResult
 }
 
-func drawBorderLeft(canvas base.TCanvas, w *Window) {
+//------------------------------------------------------------------------------
+// Internal function.
+
+func drawBorder(canvas base.TCanvas, w *Window) {
 	bounds := w.GetBounds()
+	bounds.X = 0
+	bounds.Y = 0
+
+	theme := w.GetTheme()
+
+	frameStyle := theme.StyleOf(w, base.RoleWindowFrameInactive)
+	captionStyle := theme.StyleOf(w, base.RoleWindowCaption)
+	closeStyle := theme.StyleOf(w, base.RoleWindowClose)
+	lineStyle := borderLineStyles[w.Border.Type]
 
-	leftBorderBounds := base.Rect{
-		X:      0,
-		Y:      1,
-		Width:  1,
-		Height: bounds.Height - 2,
+	if w.GetFocused() {
+		frameStyle = theme.StyleOf(w, base.RoleWindowFrameActive)
 	}
 
-	borderStyle := tcell.StyleDefault.
-		Foreground(w.Border.ForegroundColor).
-		Background(w.Border.BackgroundColor)
+	if theme.Stylesheet != nil {
+		if p := theme.Stylesheet.Properties(w); p.HasBorder {
+			lineStyle = p.Border
+		}
+	}
+
+	if w.Border.CustomColors {
+		frameStyle = tcell.StyleDefault.
+			Foreground(w.Border.ForegroundColor).
+			Background(w.Border.BackgroundColor)
+		captionStyle = frameStyle
+		closeStyle = frameStyle
+	} else {
+		frameStyle = borderColors(frameStyle, w.Border)
+		captionStyle = borderColors(captionStyle, w.Border)
+		closeStyle = borderColors(closeStyle, w.Border)
+	}
 
-	canvas.SetBrush(borderStyle)
+	if w.Border.CustomFont {
+		frameStyle = w.Border.Font.Apply(frameStyle)
+		captionStyle = w.Border.Font.Apply(captionStyle)
+		closeStyle = w.Border.Font.Apply(closeStyle)
+	}
 
-	DefaultDrawLeftOrRightBorder(canvas, leftBorderBounds, bordersChars[w.Border.Type])
-}
+	canvas.SetBrush(frameStyle)
 
-func drawBorderRight(canvas base.TCanvas, w *Window) {
-	bounds := w.GetBounds()
+	canvas.DrawRect(bounds, lineStyle)
 
-	rightBorderBounds := base.Rect{
-		X:      bounds.Width - 1,
-		Y:      1,
-		Width:  1,
-		Height: bounds.Height - 2,
+	bounds.Height = 1
+
+	canvas.SetBrush(captionStyle)
+
+	DefaultDrawTitle(canvas, bounds, w.Caption)
+
+	// Close button is draw only if enought space.
+	if bounds.Width >= minimumTitleBar {
+		canvas.PrintCharWithBrush(3, 0, CloseChar, closeStyle)
 	}
+}
 
-	borderStyle := tcell.StyleDefault.
-		Foreground(w.Border.ForegroundColor).
-		Background(w.Border.BackgroundColor)
+// Replace colors of theme style by border colors set (not ColorDefault).
+func borderColors(style tcell.Style, border WindowBorder) tcell.Style {
+	if border.ForegroundColor != tcell.ColorDefault {
+		style = style.Foreground(border.ForegroundColor)
+	}
 
-	canvas.SetBrush(borderStyle)
+	if border.BackgroundColor != tcell.ColorDefault {
+		style = style.Background(border.BackgroundColor)
+	}
 
-	DefaultDrawLeftOrRightBorder(canvas, rightBorderBounds, bordersChars[w.Border.Type])
+	return style
 }
 
 // Manage message if it's for me.
@@ -454,14 +602,57 @@ func (w *Window) manageMyMessage(msg base.Message) {
 		bounds.Width = base.MaxInt(bounds.Width, 2)
 		bounds.Height = base.MaxInt(bounds.Height, 2)
 
+		// Repaint old area and new area.
+		w.Invalidate()
 		w.SetBounds(bounds)
-		// Redraw all components cause maybe overide a component with Zorder
-		w.view.GetMessageBus().Send(base.BuildDrawMessage(base.BroadcastHandler()))
+		w.Invalidate()
+	case base.WmLButtonDown, base.WmLButtonUp, base.WmLButtonDblClick, base.WmRButtonDown, base.WmRButtonUp:
+		// Click is for child under mouse.
+		x, y := msg.Value.(*tcell.EventMouse).Position()
+
+		if v := base.ViewAt(w, x, y); v != nil && v.Handler() != w.Handler() {
+			v.HandleMessage(base.BuildClickMouseMessage(v.Handler(), msg.Value.(*tcell.EventMouse), msg.Type))
+		} else {
+			w.view.HandleMessage(msg)
+		}
+	case base.WmKey:
+		w.manageKey(msg.Value.(*tcell.EventKey))
+		w.view.HandleMessage(base.Message{Handler: w.Handler(), Type: msg.Type, Value: msg.Value})
 	default:
+		// Children receive broadcast from HandleMessage.
+		msg.Handler = w.Handler()
 		w.view.HandleMessage(msg)
 	}
 }
 
+// Enter click default button, Esc cancel button.
+func (w *Window) manageKey(ev *tcell.EventKey) {
+	// Opened drop-down list manage Enter and Esc itself.
+	for _, v := range base.FocusedViews(w) {
+		if c, ok := v.(*ComboBox); ok && c.GetDroppedDown() {
+			return
+		}
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		// Focused button manage Enter itself.
+		for _, v := range base.FocusedViews(w) {
+			if _, ok := v.(*Button); ok {
+				return
+			}
+		}
+
+		if w.defaultButton != nil {
+			w.defaultButton.Click()
+		}
+	case tcell.KeyEscape:
+		if w.cancelButton != nil {
+			w.cancelButton.Click()
+		}
+	}
+}
+
 func calculateClientBounds(bounds base.Rect, borderType BorderType) base.Rect {
 	/*
 		  TODO window type
@@ -492,18 +683,16 @@ func calculateClientBounds(bounds base.Rect, borderType BorderType) base.Rect {
 // NewWindow create new window.
 func NewWindow(name string, message base.Bus, parentCanvas base.TCanvas) Window {
 	w := Window{
-		view:    base.NewView(name, message, parentCanvas),
-		Caption: name,
+		view:         base.NewView(name, message, parentCanvas),
+		parentCanvas: parentCanvas,
+		Caption:      name,
 		Border: WindowBorder{
 			Type:            BorderTypeSingle,
-			BackgroundColor: tcell.ColorGray,
-			ForegroundColor: tcell.ColorWhite,
+			BackgroundColor: tcell.ColorDefault,
+			ForegroundColor: tcell.ColorDefault,
 		},
 	}
 
-	w.SetBackgroundColor(tcell.ColorGray)
-	w.SetForegroundColor(tcell.ColorWhite)
-
 	return w
 }
 
@@ -531,111 +720,104 @@ func BuildDestroyWindowMessage(w *Window) base.Message {
 //------------------------------------------------------------------------------
 // Default draw functions.
 
-// DefaultDrawTitleBar default draw for title bar.
+// DefaultDrawTitle default draw for title bar. Border line must be already
+// draw (see TCanvas.DrawRect), only close button and caption are draw.
 // Give ┌─[■]─ My title ─┐
-func DefaultDrawTitleBar(canvas base.TCanvas, titleBounds base.Rect, caption string, borders []rune) {
-	indexTitleBar := 0
-
-	canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[ULCorner]) // [0]
-
-	indexTitleBar++
-
-	const minimumTitleBar = 7
-
+func DefaultDrawTitle(canvas base.TCanvas, titleBounds base.Rect, caption string) {
 	// Draw close only if available space for
 	// ┌─[■]─┐
+	if titleBounds.Width < minimumTitleBar {
+		return
+	}
+
+	canvas.PrintChar(titleBounds.X+2, titleBounds.Y, CloseLeftChar)
+	// TODO use a button
+	canvas.PrintChar(titleBounds.X+3, titleBounds.Y, CloseChar)
+	canvas.PrintChar(titleBounds.X+4, titleBounds.Y, CloseRightChar)
+
 	// Need space before and after caption -> +2
-	if titleBounds.Width >= minimumTitleBar {
-		const minimumTitleBarWithCaption = minimumTitleBar + 2
-
-		canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[HLine]) // [1]
-		indexTitleBar++
-		canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[CloseLeft]) // [2]
-		indexTitleBar++
-		// TODO use a button
-		canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[Close]) // [3]
-		indexTitleBar++
-		canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[CloseRight]) // [4]
-		indexTitleBar++
-
-		paddingLen := titleBounds.Width - len(caption) - 2
-		paddingLenLeft := (paddingLen / 2)
-
-		// Need space before and after caption -> +2
-		// ─── Title ────
-		if titleBounds.Width > minimumTitleBarWithCaption {
-			// Border before caption
-			// ──────── Hello
-			for ; indexTitleBar < paddingLenLeft; indexTitleBar++ {
-				canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[HLine])
-			}
+	// ─── Title ────
+	const minimumTitleBarWithCaption = minimumTitleBar + 2
 
-			canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[CaptionSpace])
-			indexTitleBar++
+	if titleBounds.Width <= minimumTitleBarWithCaption {
+		// No space to draw caption
+		return
+	}
 
-			var c []rune
+	c := caption
+	captionWidth := canvas.MeasureString(c)
 
-			if titleBounds.Width > len(caption)+minimumTitleBarWithCaption {
-				c = []rune(caption)
-			} else {
-				// If we don't have enought space to draw title
-				len := titleBounds.Width - minimumTitleBarWithCaption
-				c = []rune(caption[:len])
-			}
+	if titleBounds.Width <= captionWidth+minimumTitleBarWithCaption {
+		// If we don't have enought space to draw title
+		c = base.TruncateString(caption, titleBounds.Width-minimumTitleBarWithCaption)
+		captionWidth = canvas.MeasureString(c)
+	}
 
-			// Draw caption
-			for i := 0; i < len(c); i++ {
-				canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, c[i])
-				indexTitleBar++
-			}
+	// Center caption after close button
+	indexTitleBar := base.MaxInt((titleBounds.Width-captionWidth-2)/2, 5)
 
-			canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[CaptionSpace])
-			indexTitleBar++
+	canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, CaptionSpaceChar)
+	indexTitleBar++
 
-			// Border after caption
-			// Hello ────
-			// -1 cause last char is corner
-			for ; indexTitleBar < titleBounds.Width-1; indexTitleBar++ {
-				canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[HLine])
-			}
+	indexTitleBar += canvas.PrintString(titleBounds.X+indexTitleBar, titleBounds.Y, c)
 
-			// TODO add up/down button
+	canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, CaptionSpaceChar)
 
-		} else {
-			// No space to draw caption
-			for ; indexTitleBar < titleBounds.Width-1; indexTitleBar++ {
-				canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[HLine])
-			}
-		}
-	} else {
-		// No space to draw close button
-		for ; indexTitleBar < titleBounds.Width-1; indexTitleBar++ {
-			canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[HLine])
-		}
-	}
+	// TODO add up/down button
+}
+
+// DefaultDrawTitleBar default draw for title bar with border characters
+// `borders` (indexed by ULCorner...).
+// Give ┌─[■]─ My title ─┐
+//
+// Deprecated: use TCanvas.DrawRect and DefaultDrawTitle.
+func DefaultDrawTitleBar(canvas base.TCanvas, titleBounds base.Rect, caption string, borders []rune) {
+	canvas.PrintChar(titleBounds.X, titleBounds.Y, borders[ULCorner])
+	drawBorderLine(canvas, titleBounds.X+1, titleBounds.Y, titleBounds.Width-2, false, borders)
+	canvas.PrintChar(titleBounds.X+titleBounds.Width-1, titleBounds.Y, borders[URCorner])
 
-	canvas.PrintChar(titleBounds.X+indexTitleBar, titleBounds.Y, borders[URCorner])
+	DefaultDrawTitle(canvas, titleBounds, caption)
 }
 
 // DefaultDrawBottomBar draw bottom border of window.
 // Give └───────────┘
+//
+// Deprecated: use TCanvas.DrawRect.
 func DefaultDrawBottomBar(canvas base.TCanvas, bottomBounds base.Rect, borders []rune) {
-	indexTitleBar := 0
-
-	canvas.PrintChar(bottomBounds.X+indexTitleBar, bottomBounds.Y, borders[LLCorner]) // [0]
-	indexTitleBar++
-
-	// -1 cause last char is corner
-	for ; indexTitleBar < bottomBounds.Width-1; indexTitleBar++ {
-		canvas.PrintChar(bottomBounds.X+indexTitleBar, bottomBounds.Y, borders[HLine])
-	}
-
-	canvas.PrintChar(bottomBounds.X+indexTitleBar, bottomBounds.Y, borders[LRCorner])
+	canvas.PrintChar(bottomBounds.X, bottomBounds.Y, borders[LLCorner])
+	drawBorderLine(canvas, bottomBounds.X+1, bottomBounds.Y, bottomBounds.Width-2, false, borders)
+	canvas.PrintChar(bottomBounds.X+bottomBounds.Width-1, bottomBounds.Y, borders[LRCorner])
 }
 
 // DefaultDrawLeftOrRightBorder draw left or right border of window.
+//
+// Deprecated: use TCanvas.DrawRect.
 func DefaultDrawLeftOrRightBorder(canvas base.TCanvas, bounds base.Rect, borders []rune) {
-	for indexTitleBar := 0; indexTitleBar < bounds.Height; indexTitleBar++ {
-		canvas.PrintChar(bounds.X, bounds.Y+indexTitleBar, borders[VLine])
+	drawBorderLine(canvas, bounds.X, bounds.Y, bounds.Height, true, borders)
+}
+
+// Draw line of `borders`. Lines of known border types use box drawing of
+// canvas, other characters are print as is.
+func drawBorderLine(canvas base.TCanvas, x int, y int, length int, vertical bool, borders []rune) {
+	for borderType, chars := range bordersChars {
+		if chars[HLine] != borders[HLine] || chars[VLine] != borders[VLine] {
+			continue
+		}
+
+		if vertical {
+			canvas.DrawVLine(x, y, length, borderLineStyles[borderType])
+		} else {
+			canvas.DrawHLine(x, y, length, borderLineStyles[borderType])
+		}
+
+		return
+	}
+
+	for i := 0; i < length; i++ {
+		if vertical {
+			canvas.PrintChar(x, y+i, borders[VLine])
+		} else {
+			canvas.PrintChar(x+i, y, borders[HLine])
+		}
 	}
 }
@@ -0,0 +1,275 @@
+package components
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+func memoryCanvasLine(m *base.MemoryCanvas, y int) string {
+	width, _ := m.Size()
+	line := make([]rune, 0, width)
+
+	for x := 0; x < width; x++ {
+		c := m.GetCell(x, y)
+		line = append(line, c.Char)
+		line = append(line, c.Combining...)
+
+		// Second cell of wide character is not part of the line.
+		if base.MeasureString(string(c.Char)) == 2 {
+			x++
+		}
+	}
+
+	return string(line)
+}
+
+func TestWindow_DefaultDrawTitle(t *testing.T) {
+	tests := []struct {
+		caption string
+		width   int
+		result  string
+	}{
+		{"Hello", 16, "┌─[■] Hello ───┐"},
+		{"Hello", 12, "┌─[■] He… ─┐"},
+		{"日本語", 15, "┌─[■] 日本語 ─┐"},
+		{"日本語", 13, "┌─[■] 日… ──┐"},
+		{"Hello", 8, "┌─[■]──┐"},
+		{"Hello", 4, "┌──┐"},
+	}
+
+	for _, test := range tests {
+		m := base.NewMemoryCanvas(test.width, 2)
+
+		m.DrawRect(base.Rect{X: 0, Y: 0, Width: test.width, Height: 2}, base.LineStyleSingle)
+		DefaultDrawTitle(m, base.Rect{X: 0, Y: 0, Width: test.width, Height: 1}, test.caption)
+
+		if line := memoryCanvasLine(m, 0); line != test.result {
+			t.Errorf("Title bar for '%s' (%d) must be '%s'. Found '%s'", test.caption, test.width, test.result, line)
+		}
+	}
+}
+
+func TestWindow_Draw_border_type(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	tests := map[BorderType][]string{
+		BorderTypeDouble:  {"╔═[■]═╗", "║     ║", "╚═════╝"},
+		BorderTypeRounded: {"╭─[■]─╮", "│     │", "╰─────╯"},
+		BorderTypeASCII:   {"+-[■]-+", "|     |", "+-----+"},
+		BorderTypeEmpty:   {"  [■]  ", "       ", "       "},
+	}
+
+	for borderType, result := range tests {
+		m := base.NewMemoryCanvas(7, 3)
+
+		w := NewWindow("window", appConfig.Message, m)
+		w.SetBounds(base.Rect{X: 0, Y: 0, Width: 7, Height: 3})
+		w.SetVisible(true)
+		w.Border.Type = borderType
+
+		w.Draw()
+
+		for y, line := range result {
+			if l := memoryCanvasLine(m, y); l != line {
+				t.Errorf("Border %d, line %d must be '%s'. Found '%s'", borderType, y, line, l)
+			}
+		}
+	}
+}
+
+func TestWindow_Draw_shadow(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(10, 10)
+	m.PrintString(0, 3, "0123456789")
+
+	w := NewWindow("window", appConfig.Message, m)
+	w.SetBounds(base.Rect{X: 1, Y: 0, Width: 5, Height: 3})
+	w.SetVisible(true)
+	w.Shadow = true
+
+	w.Draw()
+
+	// Characters under shadow are kept.
+	if line := memoryCanvasLine(m, 3); line != "0123456789" {
+		t.Errorf("Characters under shadow must be kept. Found '%s'", line)
+	}
+
+	for _, p := range []struct{ x, y int }{{6, 1}, {6, 2}, {6, 3}, {2, 3}, {5, 3}} {
+		if c := m.GetCell(p.x, p.y); c.Style != w.GetTheme().Style(base.RoleShadow) {
+			t.Errorf("Cell (%d, %d) must be in shadow", p.x, p.y)
+		}
+	}
+
+	for _, p := range []struct{ x, y int }{{6, 0}, {1, 3}, {7, 3}} {
+		if c := m.GetCell(p.x, p.y); c.Style == w.GetTheme().Style(base.RoleShadow) {
+			t.Errorf("Cell (%d, %d) must not be in shadow", p.x, p.y)
+		}
+	}
+}
+
+func TestWindow_Invalidate_shadow(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	w := NewWindow("window", appConfig.Message, base.NewMemoryCanvas(20, 20))
+	w.SetBounds(base.Rect{X: 1, Y: 2, Width: 5, Height: 3})
+	w.Shadow = true
+
+	w.Invalidate()
+
+	msg := <-*appConfig.Message.Channel()
+
+	r := base.Rect{X: 1, Y: 2, Width: 6, Height: 4}
+
+	if msg.Type != base.WmInvalidate || msg.Value.(base.Rect) != r {
+		t.Errorf("Invalidate must include shadow %+v. Found %+v", r, msg)
+	}
+}
+
+func TestWindow_Draw_theme(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	theme := base.ClassicBlueTheme()
+
+	m := base.NewMemoryCanvas(10, 4)
+
+	w := NewWindow("window", appConfig.Message, m)
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 4})
+	w.SetVisible(true)
+	w.SetTheme(theme)
+
+	w.Draw()
+
+	checks := []struct {
+		x, y int
+		role base.StyleRole
+	}{
+		{0, 0, base.RoleWindowFrameInactive},
+		{3, 0, base.RoleWindowClose},
+		{2, 0, base.RoleWindowCaption},
+		{1, 1, base.RoleWindowClient},
+	}
+
+	for _, c := range checks {
+		if cell := m.GetCell(c.x, c.y); cell.Style != theme.Style(c.role) {
+			t.Errorf("Cell (%d, %d) must have style %s", c.x, c.y, c.role)
+		}
+	}
+
+	w.SetFocused(true)
+	w.Draw()
+
+	if cell := m.GetCell(0, 0); cell.Style != theme.Style(base.RoleWindowFrameActive) {
+		t.Error("Frame of focused window must be active")
+	}
+
+	// Border color set replaces color of theme.
+	w.Border.ForegroundColor = tcell.ColorRed
+
+	w.Draw()
+
+	if cell := m.GetCell(0, 0); cell.Style != theme.Style(base.RoleWindowFrameActive).Foreground(tcell.ColorRed) {
+		t.Error("Frame must use border foreground color")
+	}
+
+	// Custom colors replace theme.
+	st := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack)
+
+	w.Border.CustomColors = true
+	w.Border.ForegroundColor = tcell.ColorRed
+	w.Border.BackgroundColor = tcell.ColorBlack
+
+	w.Draw()
+
+	if cell := m.GetCell(3, 0); cell.Style != st {
+		t.Error("Close button must use custom border colors")
+	}
+}
+
+func TestWindow_Draw_stylesheet(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	s, e := base.ParseStylesheet(strings.NewReader("Window#editor { border: double; fg: red; }"))
+
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	theme := base.DefaultTheme()
+	theme.Stylesheet = s
+
+	m := base.NewMemoryCanvas(7, 3)
+
+	w := NewWindow("editor", appConfig.Message, m)
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 7, Height: 3})
+	w.SetVisible(true)
+	w.SetTheme(theme)
+
+	w.Draw()
+
+	if line := memoryCanvasLine(m, 0); line != "╔═[■]═╗" {
+		t.Errorf("Border must come from stylesheet. Found '%s'", line)
+	}
+
+	if fg, _, _ := m.GetCell(0, 0).Style.Decompose(); fg != tcell.ColorRed {
+		t.Error("Frame color must come from stylesheet")
+	}
+}
+
+func TestWindow_Draw_border_font(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := base.NewMemoryCanvas(7, 3)
+
+	w := NewWindow("window", appConfig.Message, m)
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 7, Height: 3})
+	w.SetVisible(true)
+	w.SetFont(base.Font{Underline: true})
+	w.Border.CustomFont = true
+	w.Border.Font = base.Font{Bold: true}
+
+	w.Draw()
+
+	for _, p := range []struct{ x, y int }{{0, 0}, {3, 0}, {0, 1}} {
+		if f := base.FontOf(m.GetCell(p.x, p.y).Style); f != w.Border.Font {
+			t.Errorf("Border cell (%d, %d) must use border font. Found %+v", p.x, p.y, f)
+		}
+	}
+
+	if f := base.FontOf(m.GetCell(1, 1).Style); f != w.GetFont() {
+		t.Errorf("Client must use window font. Found %+v", f)
+	}
+}
+
+func TestWindow_deprecated_draw_functions(t *testing.T) {
+	m := base.NewMemoryCanvas(16, 3)
+	borders := bordersChars[BorderTypeDouble]
+
+	DefaultDrawTitleBar(m, base.Rect{X: 0, Y: 0, Width: 16, Height: 1}, "Hello", borders)
+	DefaultDrawLeftOrRightBorder(m, base.Rect{X: 0, Y: 1, Width: 1, Height: 1}, borders)
+	DefaultDrawLeftOrRightBorder(m, base.Rect{X: 15, Y: 1, Width: 1, Height: 1}, borders)
+	DefaultDrawBottomBar(m, base.Rect{X: 0, Y: 2, Width: 16, Height: 1}, borders)
+
+	for y, result := range []string{"╔═[■] Hello ═══╗", "║              ║", "╚══════════════╝"} {
+		if line := memoryCanvasLine(m, y); line != result {
+			t.Errorf("Line %d must be '%s'. Found '%s'", y, result, line)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// DefaultDesktopPattern is character used to fill desktop (like TurboVision).
+const DefaultDesktopPattern = '░'
+
+// Desktop is the view under all windows. It paint background of screen.
+// Desktop can have children (e.g. wallpaper text).
+type Desktop struct {
+	// Character use to fill desktop.
+	Pattern rune
+
+	View
+}
+
+// HandleMessage is use to manage message.
+func (d *Desktop) HandleMessage(msg Message) bool {
+	switch msg.Handler {
+	case d.Handler():
+		d.manageMyMessage(msg)
+		return true
+	case BroadcastHandler():
+		d.manageMyMessage(msg)
+
+		for _, child := range d.Children() {
+			child.HandleMessage(msg)
+		}
+	default:
+		for _, child := range d.Children() {
+			if child.HandleMessage(msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Draw the desktop.
+func (d *Desktop) Draw() {
+	if !d.GetVisible() {
+		return
+	}
+
+	canvas := d.Canvas()
+
+	canvas.SetBrush(d.GetStyleOf(d, RoleDesktop))
+
+	bounds := d.GetBounds()
+
+	for y := 0; y < bounds.Height; y++ {
+		for x := 0; x < bounds.Width; x++ {
+			canvas.PrintChar(x, y, d.Pattern)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal function.
+
+// Manage message if it's for me.
+func (d *Desktop) manageMyMessage(msg Message) {
+	if d.GetOnReceiveMessage() != nil && d.GetOnReceiveMessage()(d, msg) {
+		return
+	}
+
+	switch msg.Type {
+	case WmDraw:
+		if d.GetOnDraw() != nil {
+			d.GetOnDraw()(d)
+		} else {
+			d.Draw()
+			// Redraw children.
+			for _, child := range d.Children() {
+				child.HandleMessage(BuildDrawMessage(child.Handler()))
+			}
+		}
+	case WmScreenResize, WmChangeBounds:
+		d.SetBounds(msg.Value.(Rect))
+		// Exposed area must be repaint.
+		d.GetMessageBus().Send(BuildDrawMessage(BroadcastHandler()))
+	case WmEnable:
+		d.SetEnabled(msg.Value.(bool))
+	case WmThemeChanged:
+		d.SetTheme(msg.Value.(*Theme))
+	}
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewDesktop create new desktop.
+func NewDesktop(name string, message Bus, parentCanvas TCanvas) Desktop {
+	d := Desktop{
+		Pattern: DefaultDesktopPattern,
+		View:    NewView(name, message, parentCanvas),
+	}
+
+	d.SetEnabled(true)
+	d.SetVisible(true)
+
+	return d
+}
@@ -0,0 +1,163 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestDesktop_draw_pattern(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+	defer appConfig.Screen.Fini()
+
+	if e := appConfig.Screen.Init(); e != nil {
+		t.Errorf("Can't init screen: %+v\n", e)
+	}
+
+	appConfig.Screen.Clear()
+
+	rootCanvas := CanvasTest{
+		screen: appConfig.Screen,
+		brush: tcell.StyleDefault.
+			Foreground(tcell.ColorWhite).
+			Background(tcell.ColorBlack),
+	}
+
+	st := tcell.StyleDefault.
+		Foreground(tcell.ColorWhite).
+		Background(tcell.ColorBlue)
+
+	d := NewDesktop("desktop", appConfig.Message, &rootCanvas)
+	d.SetBounds(Rect{
+		X:      0,
+		Y:      0,
+		Width:  5,
+		Height: 3,
+	})
+	d.SetForegroundColor(tcell.ColorWhite)
+	d.SetBackgroundColor(tcell.ColorBlue)
+
+	d.HandleMessage(BuildDrawMessage(BroadcastHandler()))
+
+	appConfig.Screen.Show()
+
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 3; y++ {
+			if e := checkCell(appConfig.Screen, x, y, DefaultDesktopPattern, st, t); e != nil {
+				t.Error(e)
+			}
+		}
+	}
+
+	if e := checkCell(appConfig.Screen, 5, 0, ' ', tcell.StyleDefault, t); e != nil {
+		t.Error(e)
+	}
+}
+
+func TestDesktop_draw_children(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+	defer appConfig.Screen.Fini()
+
+	if e := appConfig.Screen.Init(); e != nil {
+		t.Errorf("Can't init screen: %+v\n", e)
+	}
+
+	appConfig.Screen.Clear()
+
+	rootCanvas := CanvasTest{
+		screen: appConfig.Screen,
+		brush:  tcell.StyleDefault,
+	}
+
+	d := NewDesktop("desktop", appConfig.Message, &rootCanvas)
+	d.Pattern = '.'
+	d.SetForegroundColor(tcell.ColorDefault)
+	d.SetBackgroundColor(tcell.ColorDefault)
+	d.SetBounds(Rect{
+		X:      0,
+		Y:      0,
+		Width:  10,
+		Height: 10,
+	})
+
+	st := tcell.StyleDefault.Background(tcell.ColorRed)
+
+	child := NewView("wallpaper", appConfig.Message, d.ClientCanvas())
+	child.SetBounds(Rect{
+		X:      2,
+		Y:      2,
+		Width:  2,
+		Height: 2,
+	})
+	child.SetForegroundColor(tcell.ColorDefault)
+	child.SetBackgroundColor(tcell.ColorRed)
+	child.SetVisible(true)
+	child.SetParent(&d)
+
+	d.AddChild(&child)
+
+	d.HandleMessage(BuildDrawMessage(d.Handler()))
+
+	appConfig.Screen.Show()
+
+	if e := checkCell(appConfig.Screen, 1, 1, '.', tcell.StyleDefault, t); e != nil {
+		t.Error(e)
+	}
+
+	if e := checkCell(appConfig.Screen, 2, 2, ' ', st, t); e != nil {
+		t.Error(e)
+	}
+}
+
+func TestDesktop_screen_resize(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	mainWindow := NewView("window1", appConfig.Message, app.Canvas())
+
+	app.AddWindow(&mainWindow)
+
+	if e := app.Init(); e != nil {
+		t.Error("Cannot initialize screen")
+	}
+
+	defer appConfig.Screen.Fini()
+
+	width, height := appConfig.Screen.Size()
+
+	if b := app.Desktop().GetBounds(); b.Width != width || b.Height != height {
+		t.Errorf("Desktop must be size of screen. Found %+v", b)
+	}
+
+	r := Rect{
+		X:      0,
+		Y:      0,
+		Width:  10,
+		Height: 20,
+	}
+
+	app.Desktop().HandleMessage(Message{
+		Handler: BroadcastHandler(),
+		Type:    WmScreenResize,
+		Value:   r,
+	})
+
+	if app.Desktop().GetBounds() != r {
+		t.Errorf("Desktop must be resized. Found %+v", app.Desktop().GetBounds())
+	}
+}
@@ -0,0 +1,352 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// ExportFormat is output format of screen export.
+type ExportFormat int
+
+const (
+	// ExportText export characters only.
+	ExportText ExportFormat = 0
+	// ExportANSI export characters with ANSI escape sequences for colors and
+	// attributes.
+	ExportANSI ExportFormat = 1
+	// ExportHTML export a standalone HTML page.
+	ExportHTML ExportFormat = 2
+	// ExportSVG export a SVG image.
+	ExportSVG ExportFormat = 3
+)
+
+// Colors used in HTML and SVG export for tcell.ColorDefault.
+const (
+	exportDefaultForeground = "#c0c0c0"
+	exportDefaultBackground = "#000000"
+)
+
+// Size of a cell in SVG export.
+const (
+	svgCellWidth  = 8
+	svgCellHeight = 16
+	svgFontSize   = 14
+	svgBaseline   = 12
+)
+
+// ExportCanvas write `r` area of canvas in format.
+func ExportCanvas(w io.Writer, c TCanvas, r Rect, format ExportFormat) error {
+	return exportCells(w, readCells(c, r), format)
+}
+
+// ExportView write area of view in format.
+func ExportView(w io.Writer, v TView, format ExportFormat) error {
+	bounds := v.GetBounds()
+
+	return ExportCanvas(w, v.Canvas(), Rect{
+		X:      0,
+		Y:      0,
+		Width:  bounds.Width,
+		Height: bounds.Height,
+	}, format)
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Characters with same style.
+type cellRun struct {
+	// Position in cells.
+	x int
+	// Width in cells.
+	width int
+	text  string
+	style tcell.Style
+}
+
+// Read cells of area, line by line.
+func readCells(c TCanvas, r Rect) [][]Cell {
+	lines := make([][]Cell, 0, MaxInt(r.Height, 0))
+
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		line := make([]Cell, 0, MaxInt(r.Width, 0))
+
+		for x := r.X; x < r.X+r.Width; x++ {
+			line = append(line, c.GetCell(x, y))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// Group cells of line by style. Second cell of wide character is skipped.
+func lineRuns(line []Cell) []cellRun {
+	runs := make([]cellRun, 0)
+
+	for x := 0; x < len(line); x++ {
+		cell := line[x]
+		width := MaxInt(runewidth.RuneWidth(cell.Char), 1)
+		text := string(cell.Char) + string(cell.Combining)
+
+		if cell.Char == 0 {
+			text = " "
+		}
+
+		last := len(runs) - 1
+
+		if last >= 0 && runs[last].style == cell.Style {
+			runs[last].text += text
+			runs[last].width += width
+		} else {
+			runs = append(runs, cellRun{
+				x:     x,
+				width: width,
+				text:  text,
+				style: cell.Style,
+			})
+		}
+
+		x += width - 1
+	}
+
+	return runs
+}
+
+func exportCells(w io.Writer, lines [][]Cell, format ExportFormat) error {
+	switch format {
+	case ExportText:
+		return exportText(w, lines)
+	case ExportANSI:
+		return exportANSI(w, lines)
+	case ExportHTML:
+		return exportHTML(w, lines)
+	case ExportSVG:
+		return exportSVG(w, lines)
+	default:
+		return errors.New("unknown export format")
+	}
+}
+
+func exportText(w io.Writer, lines [][]Cell) error {
+	var b strings.Builder
+
+	for _, line := range lines {
+		for _, run := range lineRuns(line) {
+			b.WriteString(run.text)
+		}
+
+		b.WriteString("\n")
+	}
+
+	_, e := io.WriteString(w, b.String())
+
+	return e
+}
+
+func exportANSI(w io.Writer, lines [][]Cell) error {
+	var b strings.Builder
+
+	for _, line := range lines {
+		for _, run := range lineRuns(line) {
+			b.WriteString(ansiStyle(run.style))
+			b.WriteString(run.text)
+		}
+
+		b.WriteString("\x1b[0m\n")
+	}
+
+	_, e := io.WriteString(w, b.String())
+
+	return e
+}
+
+// Return SGR sequence of style.
+func ansiStyle(st tcell.Style) string {
+	fg, bg, attr := st.Decompose()
+
+	codes := []string{"0"}
+
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+	} {
+		if attr&a.mask != 0 {
+			codes = append(codes, a.code)
+		}
+	}
+
+	codes = append(codes, ansiColor(fg, 30), ansiColor(bg, 40))
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// Return SGR code of color. `base` is 30 for foreground, 40 for background.
+func ansiColor(c tcell.Color, base int) string {
+	switch {
+	case c == tcell.ColorDefault:
+		return fmt.Sprintf("%d", base+9)
+	case c >= 0 && c < 8:
+		return fmt.Sprintf("%d", base+int(c))
+	case c >= 8 && c < 16:
+		return fmt.Sprintf("%d", base+60+int(c)-8)
+	case c >= 16 && c < 256:
+		return fmt.Sprintf("%d;5;%d", base+8, c)
+	case c.Hex() >= 0:
+		// RGB or named color out of palette.
+		r, g, b := c.RGB()
+
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	default:
+		return fmt.Sprintf("%d", base+9)
+	}
+}
+
+// Return CSS color, or `def` if color is unknown.
+func cssColor(c tcell.Color, def string) string {
+	if v := c.Hex(); v >= 0 {
+		return fmt.Sprintf("#%06x", v)
+	}
+
+	return def
+}
+
+// Return foreground and background CSS colors. Reverse attribute is applied.
+func cssColors(st tcell.Style) (string, string) {
+	fg, bg, attr := st.Decompose()
+
+	fgColor := cssColor(fg, exportDefaultForeground)
+	bgColor := cssColor(bg, exportDefaultBackground)
+
+	if attr&tcell.AttrReverse != 0 {
+		return bgColor, fgColor
+	}
+
+	return fgColor, bgColor
+}
+
+func exportHTML(w io.Writer, lines [][]Cell) error {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>GoVision screen</title>\n</head>\n")
+	b.WriteString(fmt.Sprintf("<body style=\"background: %s\">\n", exportDefaultBackground))
+	b.WriteString(fmt.Sprintf("<pre style=\"font-family: monospace; line-height: 1; color: %s; background: %s\">",
+		exportDefaultForeground, exportDefaultBackground))
+
+	for _, line := range lines {
+		for _, run := range lineRuns(line) {
+			fg, bg := cssColors(run.style)
+			_, _, attr := run.style.Decompose()
+
+			css := fmt.Sprintf("color: %s; background: %s", fg, bg)
+
+			if attr&tcell.AttrBold != 0 {
+				css += "; font-weight: bold"
+			}
+
+			if attr&tcell.AttrDim != 0 {
+				css += "; opacity: 0.5"
+			}
+
+			if attr&tcell.AttrUnderline != 0 {
+				css += "; text-decoration: underline"
+			}
+
+			if attr&tcell.AttrBlink != 0 {
+				css += "; text-decoration: blink"
+			}
+
+			b.WriteString(fmt.Sprintf("<span style=\"%s\">%s</span>", css, html.EscapeString(run.text)))
+		}
+
+		b.WriteString("\n")
+	}
+
+	b.WriteString("</pre>\n</body>\n</html>\n")
+
+	_, e := io.WriteString(w, b.String())
+
+	return e
+}
+
+func exportSVG(w io.Writer, lines [][]Cell) error {
+	var b strings.Builder
+
+	width := 0
+
+	if len(lines) > 0 {
+		width = len(lines[0])
+	}
+
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" "+
+		"font-family=\"monospace\" font-size=\"%d\" xml:space=\"preserve\">\n",
+		width*svgCellWidth, len(lines)*svgCellHeight, svgFontSize))
+	b.WriteString(fmt.Sprintf("<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", exportDefaultBackground))
+
+	for y, line := range lines {
+		for _, run := range lineRuns(line) {
+			fg, bg := cssColors(run.style)
+			_, _, attr := run.style.Decompose()
+
+			x := run.x * svgCellWidth
+			runWidth := run.width * svgCellWidth
+
+			b.WriteString(fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n",
+				x, y*svgCellHeight, runWidth, svgCellHeight, bg))
+
+			if strings.TrimSpace(run.text) == "" && attr&tcell.AttrUnderline == 0 {
+				continue
+			}
+
+			text := fmt.Sprintf("<text x=\"%d\" y=\"%d\" textLength=\"%d\" lengthAdjust=\"spacingAndGlyphs\" fill=\"%s\"",
+				x, y*svgCellHeight+svgBaseline, runWidth, fg)
+
+			if attr&tcell.AttrBold != 0 {
+				text += " font-weight=\"bold\""
+			}
+
+			if attr&tcell.AttrDim != 0 {
+				text += " opacity=\"0.5\""
+			}
+
+			if attr&tcell.AttrUnderline != 0 {
+				text += " text-decoration=\"underline\""
+			}
+
+			b.WriteString(fmt.Sprintf("%s>%s</text>\n", text, html.EscapeString(run.text)))
+		}
+	}
+
+	b.WriteString("</svg>\n")
+
+	_, e := io.WriteString(w, b.String())
+
+	return e
+}
@@ -0,0 +1,133 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func createExportCanvas() *MemoryCanvas {
+	m := NewMemoryCanvas(4, 2)
+	m.PrintString(0, 0, "a<日")
+	m.PrintStringWithBrush(0, 1, "b", tcell.StyleDefault.
+		Foreground(tcell.ColorRed).
+		Background(tcell.ColorBlue).
+		Bold(true))
+
+	return m
+}
+
+func export(t *testing.T, c TCanvas, r Rect, format ExportFormat) string {
+	var b bytes.Buffer
+
+	if e := ExportCanvas(&b, c, r, format); e != nil {
+		t.Errorf("Export fail: %s", e)
+	}
+
+	return b.String()
+}
+
+func TestExport_text(t *testing.T) {
+	m := createExportCanvas()
+
+	result := export(t, m, Rect{X: 0, Y: 0, Width: 4, Height: 2}, ExportText)
+
+	if result != "a<日\nb   \n" {
+		t.Errorf("Wrong text export. Found '%s'", result)
+	}
+
+	// Only a region.
+	result = export(t, m, Rect{X: 1, Y: 0, Width: 2, Height: 1}, ExportText)
+
+	if result != "<日\n" {
+		t.Errorf("Wrong text export of region. Found '%s'", result)
+	}
+}
+
+func TestExport_ansi(t *testing.T) {
+	m := createExportCanvas()
+
+	result := export(t, m, Rect{X: 0, Y: 1, Width: 2, Height: 1}, ExportANSI)
+
+	if result != "\x1b[0;1;91;104mb\x1b[0;39;49m \x1b[0m\n" {
+		t.Errorf("Wrong ANSI export. Found %q", result)
+	}
+
+	if c := ansiColor(tcell.Color(119), 30); c != "38;5;119" {
+		t.Errorf("Wrong 256 colors code. Found %s", c)
+	}
+
+	if c := ansiColor(tcell.NewRGBColor(1, 2, 3), 40); c != "48;2;1;2;3" {
+		t.Errorf("Wrong RGB colors code. Found %s", c)
+	}
+
+	if c := ansiColor(tcell.ColorYellow, 40); c != "103" {
+		t.Errorf("Wrong bright colors code. Found %s", c)
+	}
+
+	if c := ansiColor(tcell.ColorAliceBlue, 30); c != "38;2;240;248;255" {
+		t.Errorf("Wrong named colors code. Found %s", c)
+	}
+
+	if c := ansiColor(tcell.ColorMaroon, 30); c != "31" {
+		t.Errorf("Wrong basic colors code. Found %s", c)
+	}
+}
+
+func TestExport_html(t *testing.T) {
+	m := createExportCanvas()
+
+	result := export(t, m, Rect{X: 0, Y: 0, Width: 4, Height: 2}, ExportHTML)
+
+	for _, s := range []string{
+		"<!DOCTYPE html>",
+		">a&lt;日</span>",
+		"<span style=\"color: #ff0000; background: #0000ff; font-weight: bold\">b</span>",
+		"</html>",
+	} {
+		if !strings.Contains(result, s) {
+			t.Errorf("HTML export must contain '%s'. Found '%s'", s, result)
+		}
+	}
+}
+
+func TestExport_svg(t *testing.T) {
+	m := createExportCanvas()
+
+	result := export(t, m, Rect{X: 0, Y: 0, Width: 4, Height: 2}, ExportSVG)
+
+	for _, s := range []string{
+		"width=\"32\" height=\"32\"",
+		"<rect x=\"0\" y=\"16\" width=\"8\" height=\"16\" fill=\"#0000ff\"/>",
+		"<text x=\"0\" y=\"28\" textLength=\"8\" lengthAdjust=\"spacingAndGlyphs\" fill=\"#ff0000\" font-weight=\"bold\">b</text>",
+		">a&lt;日</text>",
+		"</svg>",
+	} {
+		if !strings.Contains(result, s) {
+			t.Errorf("SVG export must contain '%s'. Found '%s'", s, result)
+		}
+	}
+}
+
+func TestExport_unknown_format(t *testing.T) {
+	var b bytes.Buffer
+
+	if e := ExportCanvas(&b, NewMemoryCanvas(1, 1), Rect{X: 0, Y: 0, Width: 1, Height: 1}, ExportFormat(99)); e == nil {
+		t.Error("Unknown format must return error")
+	}
+}
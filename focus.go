@@ -0,0 +1,53 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// TopLevel return top-level ancestor of component (window, desktop) or
+// component itself if it has no parent.
+func TopLevel(c TComponent) TComponent {
+	for c.GetParent() != nil {
+		c = c.GetParent()
+	}
+
+	return c
+}
+
+// FocusedViews return focused descendants of component. Component itself is
+// not included.
+func FocusedViews(c TComponent) []TView {
+	var views []TView
+
+	for _, child := range c.Children() {
+		if v, ok := child.(TView); ok && v.GetFocused() {
+			views = append(views, v)
+		}
+
+		views = append(views, FocusedViews(child)...)
+	}
+
+	return views
+}
+
+// SetFocus give focus to view. Other focused views of same top-level view
+// lose focus. Activate messages are sent by bus.
+func SetFocus(v TView) {
+	for _, focused := range FocusedViews(TopLevel(v)) {
+		if focused.Handler() != v.Handler() {
+			v.GetMessageBus().Send(BuildDesactivateMessage(focused.Handler()))
+		}
+	}
+
+	v.GetMessageBus().Send(BuildActivateMessage(v.Handler()))
+}
@@ -0,0 +1,66 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+)
+
+func TestFocus_SetFocus(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := NewMemoryCanvas(10, 10)
+
+	root := NewView("root", appConfig.Message, m)
+	root.SetFocused(true)
+
+	views := make([]*View, 3)
+
+	for i := range views {
+		v := NewView("child", appConfig.Message, root.ClientCanvas())
+		views[i] = &v
+	}
+
+	views[0].SetParent(&root)
+	root.AddChild(views[0])
+	views[1].SetParent(views[0])
+	views[0].AddChild(views[1])
+	views[2].SetParent(&root)
+	root.AddChild(views[2])
+
+	if TopLevel(views[1]) != &root {
+		t.Error("Top level of grand child must be root")
+	}
+
+	views[1].SetFocused(true)
+
+	if f := FocusedViews(&root); len(f) != 1 || f[0] != views[1] {
+		t.Errorf("Focused view must be grand child. Found %v", f)
+	}
+
+	SetFocus(views[2])
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Handler != views[1].Handler() || msg.Type != WmActivate || msg.Value != WaInactive {
+		t.Errorf("Previous focused view must be desactivated. Found %+v", msg)
+	}
+
+	msg = <-*appConfig.Message.Channel()
+
+	if msg.Handler != views[2].Handler() || msg.Type != WmActivate || msg.Value != WaActive {
+		t.Errorf("View must be activated. Found %+v", msg)
+	}
+}
@@ -0,0 +1,54 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// Font is text attributes of view.
+type Font struct {
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+	Blink     bool
+}
+
+// FontOf return font with attributes of style.
+func FontOf(st tcell.Style) Font {
+	_, _, attr := st.Decompose()
+
+	return Font{
+		Bold:      attr&tcell.AttrBold != 0,
+		Dim:       attr&tcell.AttrDim != 0,
+		Italic:    attr&tcell.AttrItalic != 0,
+		Underline: attr&tcell.AttrUnderline != 0,
+		Reverse:   attr&tcell.AttrReverse != 0,
+		Blink:     attr&tcell.AttrBlink != 0,
+	}
+}
+
+// Apply replace attributes of style by font attributes. Colors are kept.
+func (f Font) Apply(st tcell.Style) tcell.Style {
+	return st.
+		Bold(f.Bold).
+		Dim(f.Dim).
+		Italic(f.Italic).
+		Underline(f.Underline).
+		Reverse(f.Reverse).
+		Blink(f.Blink)
+}
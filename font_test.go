@@ -0,0 +1,60 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestFont_Apply(t *testing.T) {
+	st := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Reverse(true)
+
+	f := Font{Bold: true, Italic: true, Underline: true, Blink: true}
+
+	if s := f.Apply(st); s != tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Bold(true).Italic(true).Underline(true).Blink(true) {
+		t.Error("Font must replace attributes and keep colors")
+	}
+
+	if FontOf(f.Apply(st)) != f {
+		t.Errorf("Font of style must be %+v. Found %+v", f, FontOf(f.Apply(st)))
+	}
+}
+
+func TestView_SetFont(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	theme := DefaultTheme()
+	theme.SetStyle(RoleView, theme.Style(RoleView).Dim(true))
+
+	m := NewMemoryCanvas(4, 1)
+
+	v := NewView("view", appConfig.Message, m)
+	v.SetBounds(Rect{X: 0, Y: 0, Width: 4, Height: 1})
+	v.SetVisible(true)
+	v.SetTheme(theme)
+
+	if !FontOf(v.GetStyle(RoleView)).Dim {
+		t.Error("Without font, attributes of theme must be used")
+	}
+
+	v.SetFont(Font{Bold: true, Reverse: true})
+	v.Draw()
+
+	if f := FontOf(m.GetCell(0, 0).Style); f != v.GetFont() {
+		t.Errorf("View must be draw with font %+v. Found %+v", v.GetFont(), f)
+	}
+}
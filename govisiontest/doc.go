@@ -0,0 +1,21 @@
+// Package govisiontest run GoVision application without terminal for tests.
+//
+// Harness start an Application on a tcell.SimulationScreen and manage
+// messages step by step (no event loop, no sleep). Screen can be compared
+// with golden text files. Run tests with -update-golden to write golden
+// files.
+package govisiontest
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
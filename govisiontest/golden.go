@@ -0,0 +1,88 @@
+package govisiontest
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Write golden files instead of compare.
+var updateGolden = flag.Bool("update-golden", false, "write golden files of govisiontest")
+
+// AssertGolden compare screen with golden text file. With -update-golden
+// flag, golden file is written.
+func (h *Harness) AssertGolden(filename string) {
+	h.t.Helper()
+
+	got := h.ScreenText()
+
+	if *updateGolden {
+		if e := os.MkdirAll(filepath.Dir(filename), 0755); e != nil {
+			h.t.Fatalf("Cannot create directory of golden file: %s", e)
+		}
+
+		if e := ioutil.WriteFile(filename, []byte(got), 0644); e != nil {
+			h.t.Fatalf("Cannot write golden file: %s", e)
+		}
+
+		return
+	}
+
+	want, e := ioutil.ReadFile(filename)
+
+	if e != nil {
+		h.t.Errorf("Cannot read golden file (run test with -update-golden to create it): %s", e)
+
+		return
+	}
+
+	if diff := diffLines(string(want), got); diff != "" {
+		h.t.Errorf("Screen is different of golden file %s:\n%s", filename, diff)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Return different lines, or empty string if same.
+func diffLines(want string, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var b strings.Builder
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+
+		if w != g {
+			fmt.Fprintf(&b, "line %d:\n- want: %q\n+ got:  %q\n", i+1, w, g)
+		}
+	}
+
+	return b.String()
+}
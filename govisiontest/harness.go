@@ -0,0 +1,200 @@
+package govisiontest
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/gdamore/tcell"
+)
+
+// Harness run an application on a simulation screen.
+type Harness struct {
+	// App is application to test. Add windows before call Start.
+	App *base.Application
+	// Config of application (message bus...).
+	Config base.ApplicationConfig
+	// Screen is simulation screen of application.
+	Screen tcell.SimulationScreen
+
+	t       testing.TB
+	width   int
+	height  int
+	running bool
+}
+
+// Start initialize screen and draw first frame.
+func (h *Harness) Start() {
+	h.t.Helper()
+
+	if e := h.App.Init(); e != nil {
+		h.t.Fatalf("Cannot initialize screen: %s", e)
+	}
+
+	h.Screen.SetSize(h.width, h.height)
+	h.Config.Message.Send(base.BuildScreenResizeMessage(h.Screen))
+
+	h.App.Start()
+	h.running = true
+
+	h.WaitIdle()
+}
+
+// Close release screen.
+func (h *Harness) Close() {
+	h.Screen.Fini()
+}
+
+// Running return false if application is stopped (Ctrl+C, main window
+// destroyed...).
+func (h *Harness) Running() bool {
+	return h.running
+}
+
+// WaitIdle manage all pending messages and draw frame.
+func (h *Harness) WaitIdle() {
+	if h.running {
+		h.running = h.App.Step()
+	}
+}
+
+// Send a message and wait idle.
+func (h *Harness) Send(msg base.Message) {
+	h.Config.Message.Send(msg)
+	h.WaitIdle()
+}
+
+// Key press a key.
+func (h *Harness) Key(key tcell.Key, r rune, mod tcell.ModMask) {
+	h.Send(base.BuildKeyMessage(tcell.NewEventKey(key, r, mod)))
+}
+
+// Type press a key for each character of text.
+func (h *Harness) Type(text string) {
+	for _, r := range text {
+		h.Key(tcell.KeyRune, r, tcell.ModNone)
+	}
+}
+
+// Click left click at screen position.
+func (h *Harness) Click(x int, y int) {
+	h.Send(base.BuildMouseMessage(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone)))
+	h.Send(base.BuildMouseMessage(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone)))
+}
+
+// Wheel turn mouse wheel at screen position. `wheel` is tcell.WheelUp,
+// tcell.WheelDown...
+func (h *Harness) Wheel(x int, y int, wheel tcell.ButtonMask) {
+	h.Send(base.BuildMouseMessage(tcell.NewEventMouse(x, y, wheel, tcell.ModNone)))
+}
+
+// ClickOn left click on center of view with name. Top window is searched
+// first.
+func (h *Harness) ClickOn(name string) {
+	h.t.Helper()
+
+	v := h.FindView(name)
+
+	if v == nil {
+		h.t.Fatalf("View '%s' not found", name)
+
+		return
+	}
+
+	bounds := base.AbsoluteBounds(v)
+
+	h.Click(bounds.X+bounds.Width/2, bounds.Y+bounds.Height/2)
+}
+
+// FindView return view with name or nil. Top window is searched first, then
+// its children.
+func (h *Harness) FindView(name string) base.TView {
+	for _, w := range h.App.WindowsList() {
+		if v := findView(w, name); v != nil {
+			return v
+		}
+	}
+
+	return findView(h.App.Desktop(), name)
+}
+
+// ScreenText return screen as text. Trailing spaces of lines are removed.
+func (h *Harness) ScreenText() string {
+	h.t.Helper()
+
+	var b bytes.Buffer
+
+	if e := h.App.ExportScreen(&b, base.ExportText); e != nil {
+		h.t.Fatalf("Cannot read screen: %s", e)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func findView(c base.TComponent, name string) base.TView {
+	if v, ok := c.(base.TView); ok && c.Name() == name {
+		return v
+	}
+
+	for _, child := range c.Children() {
+		if v := findView(child, name); v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewHarness create an application with a screen of width x height cells.
+// Screen is white on black.
+func NewHarness(t testing.TB, width int, height int) *Harness {
+	screen := tcell.NewSimulationScreen("UTF-8")
+
+	config := base.ApplicationConfig{
+		ScreenStyle: base.ApplicationStyle{
+			Style:           tcell.StyleDefault,
+			ForegroundColor: tcell.ColorWhite,
+			BackgroundColor: tcell.ColorBlack,
+		},
+		Screen:  screen,
+		Message: base.NewBus(),
+	}
+
+	app := base.NewApplication(config)
+
+	return &Harness{
+		App:    &app,
+		Config: config,
+		Screen: screen,
+		t:      t,
+		width:  width,
+		height: height,
+	}
+}
@@ -0,0 +1,247 @@
+package govisiontest
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/components"
+	"github.com/gdamore/tcell"
+)
+
+// Record errors instead of fail test.
+type recordTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordTB) Helper() {}
+
+func (r *recordTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func createWindows(h *Harness) (*components.Window, *components.Window) {
+	back := components.NewWindow("back", h.Config.Message, h.App.Canvas())
+	back.SetBounds(base.Rect{X: 1, Y: 1, Width: 16, Height: 5})
+	back.SetVisible(true)
+	back.SetEnabled(true)
+
+	front := components.NewWindow("front", h.Config.Message, h.App.Canvas())
+	front.SetBounds(base.Rect{X: 12, Y: 3, Width: 16, Height: 5})
+	front.SetVisible(true)
+	front.SetEnabled(true)
+	front.Shadow = true
+
+	h.App.AddWindow(&back)
+	h.App.AddWindow(&front)
+
+	return &back, &front
+}
+
+func TestHarness_golden(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	createWindows(h)
+
+	h.Start()
+
+	h.AssertGolden("testdata/windows.golden")
+}
+
+func TestHarness_ClickOn(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	back, _ := createWindows(h)
+
+	h.Start()
+
+	h.ClickOn("back")
+
+	if h.App.ActiveWindow() != back {
+		t.Errorf("Window 'back' must be active. Found '%s'", h.App.ActiveWindow().Name())
+	}
+
+	h.AssertGolden("testdata/click_on.golden")
+}
+
+func TestHarness_Type(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	_, front := createWindows(h)
+
+	typed := ""
+
+	front.SetOnReceiveMessage(func(c base.TComponent, msg base.Message) bool {
+		if msg.Type == base.WmKey {
+			typed += string(msg.Value.(*tcell.EventKey).Rune())
+		}
+
+		return false
+	})
+
+	h.Start()
+
+	h.Type("Hello, world!")
+
+	if typed != "Hello, world!" {
+		t.Errorf("Active window must receive all keys. Found '%s'", typed)
+	}
+
+	h.Key(tcell.KeyCtrlC, 0, tcell.ModCtrl)
+
+	if h.Running() {
+		t.Error("Application must stop on Ctrl+C")
+	}
+}
+
+func TestHarness_AssertGolden_different(t *testing.T) {
+	if *updateGolden {
+		t.Skip("Golden files are written")
+	}
+
+	r := &recordTB{}
+
+	h := NewHarness(r, 30, 10)
+	defer h.Close()
+
+	createWindows(h)
+
+	h.Start()
+
+	h.AssertGolden("testdata/click_on.golden")
+
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "line 4:") {
+		t.Errorf("Golden file must be different at line 4. Found %v", r.errors)
+	}
+
+	r.errors = nil
+
+	h.AssertGolden("testdata/not_exists.golden")
+
+	if len(r.errors) != 1 {
+		t.Error("Missing golden file must be an error")
+	}
+}
+
+func TestHarness_FindView(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	back, _ := createWindows(h)
+
+	child := base.NewView("child", h.Config.Message, back.ClientCanvas())
+	child.SetParent(back)
+	back.AddChild(&child)
+
+	if h.FindView("child") != &child {
+		t.Error("Child view must be found")
+	}
+
+	if h.FindView("desktop") != h.App.Desktop() {
+		t.Error("Desktop must be found")
+	}
+
+	if h.FindView("unknown") != nil {
+		t.Error("Unknown view must be nil")
+	}
+}
+
+func TestHarness_Wheel_scroll_view_in_window(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	w := components.NewWindow("window", h.Config.Message, h.App.Canvas())
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 12, Height: 6})
+	w.SetVisible(true)
+	w.SetEnabled(true)
+
+	s := components.NewScrollView("scroll", h.Config.Message, w.ClientCanvas())
+	s.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 4})
+	s.SetVisible(true)
+	s.SetParent(&w)
+	s.SetContentSize(10, 20)
+	w.AddChild(&s)
+
+	child := base.NewView("child", h.Config.Message, s.ClientCanvas())
+	child.SetBounds(base.Rect{X: 0, Y: 0, Width: 10, Height: 2})
+	child.SetVisible(true)
+	child.SetParent(&s)
+	s.AddChild(&child)
+
+	h.App.AddWindow(&w)
+
+	h.Start()
+
+	bounds := base.AbsoluteBounds(&child)
+
+	// Wheel on child is sent to scroll view.
+	h.Wheel(bounds.X+1, bounds.Y+1, tcell.WheelDown)
+
+	if _, y := s.GetScroll(); y != components.DefaultWheelStep {
+		t.Errorf("Scroll view must scroll of %d lines. Found %d", components.DefaultWheelStep, y)
+	}
+
+	if v := h.FindView("child"); base.AbsoluteBounds(v).Y != bounds.Y-components.DefaultWheelStep {
+		t.Error("Child must move with content")
+	}
+}
+
+func TestHarness_ClickOn_button(t *testing.T) {
+	h := NewHarness(t, 30, 10)
+	defer h.Close()
+
+	w := components.NewWindow("dialog", h.Config.Message, h.App.Canvas())
+	w.SetBounds(base.Rect{X: 0, Y: 0, Width: 20, Height: 6})
+	w.SetVisible(true)
+	w.SetEnabled(true)
+
+	clicks := 0
+
+	b := components.NewButton("ok", h.Config.Message, w.ClientCanvas())
+	b.SetBounds(base.Rect{X: 2, Y: 2, Width: 8, Height: 2})
+	b.SetCaption("OK")
+	b.SetParent(&w)
+	b.OnClick = func(*components.Button) {
+		clicks++
+	}
+	w.AddChild(&b)
+
+	h.App.AddWindow(&w)
+
+	h.Start()
+
+	h.ClickOn("ok")
+
+	if clicks != 1 || !b.GetFocused() {
+		t.Errorf("Button must be clicked once and focused. Found %d", clicks)
+	}
+
+	if !b.GetHover() {
+		t.Error("Mouse is over button")
+	}
+
+	h.Key(tcell.KeyRune, ' ', tcell.ModNone)
+
+	if clicks != 2 {
+		t.Errorf("Space must click focused button once. Found %d", clicks)
+	}
+}
@@ -24,8 +24,9 @@ func PrintStringOnScreen(screen tcell.Screen, bc tcell.Color, fc tcell.Color, x
 		Foreground(fc).
 		Background(bc)
 
-	for pos, char := range msg {
-		screen.SetContent(x+pos, y, char, nil, style)
+	for _, g := range SplitGraphemes(msg) {
+		screen.SetContent(x, y, g.Char, g.Combining, style)
+		x += g.Width
 	}
 }
 
@@ -91,3 +92,94 @@ func Intersect(r1 Rect, r2 Rect) Rect {
 		Height: height,
 	}
 }
+
+// Union return smallest rectangle that contains r1 and r2.
+func Union(r1 Rect, r2 Rect) Rect {
+	if IsEmpty(r1) {
+		return r2
+	}
+
+	if IsEmpty(r2) {
+		return r1
+	}
+
+	leftX := MinInt(r1.X, r2.X)
+	topY := MinInt(r1.Y, r2.Y)
+
+	return Rect{
+		X:      leftX,
+		Y:      topY,
+		Width:  MaxInt(r1.X+r1.Width, r2.X+r2.Width) - leftX,
+		Height: MaxInt(r1.Y+r1.Height, r2.Y+r2.Height) - topY,
+	}
+}
+
+// IsEmpty return true if rectangle has no area.
+func IsEmpty(r Rect) bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains return true if r2 is fully in r1.
+func Contains(r1 Rect, r2 Rect) bool {
+	return r2.X >= r1.X && r2.Y >= r1.Y &&
+		r2.X+r2.Width <= r1.X+r1.Width &&
+		r2.Y+r2.Height <= r1.Y+r1.Height
+}
+
+// AbsoluteBounds return bounds of view in screen coordinates. Top-level view
+// (without parent) bounds are already in screen coordinates.
+func AbsoluteBounds(v TView) Rect {
+	bounds := v.GetBounds()
+
+	for p := v.GetParent(); p != nil; p = p.GetParent() {
+		parent, ok := p.(TView)
+
+		if !ok {
+			break
+		}
+
+		parentBounds := parent.GetBounds()
+		clientBounds := parent.GetClientBounds()
+
+		bounds.X += parentBounds.X + clientBounds.X
+		bounds.Y += parentBounds.Y + clientBounds.Y
+	}
+
+	return bounds
+}
+
+// ViewAt return deepest visible view under screen position (x, y), `v` if no
+// child is under position or nil if position is out of `v`.
+func ViewAt(v TView, x, y int) TView {
+	return viewAt(v, x, y, AbsoluteBounds(v))
+}
+
+// Children are clipped by `clip` area of parent.
+func viewAt(v TView, x, y int, clip Rect) TView {
+	bounds := Intersect(AbsoluteBounds(v), clip)
+
+	if !v.GetVisible() || !InHorizontal(x, bounds) || !InVertical(y, bounds) {
+		return nil
+	}
+
+	children := v.Children()
+
+	// Last child is draw above others.
+	for i := len(children) - 1; i >= 0; i-- {
+		if child, ok := children[i].(TView); ok {
+			if found := viewAt(child, x, y, bounds); found != nil {
+				return found
+			}
+		}
+	}
+
+	return v
+}
+
+// SendWheelToParent send wheel event to parent of component (e.g. component
+// can't scroll or is at scroll limit).
+func SendWheelToParent(c TComponent, ev *tcell.EventMouse) {
+	if p := c.GetParent(); p != nil {
+		c.GetMessageBus().Send(BuildMouseWheelMessage(p.Handler(), ev))
+	}
+}
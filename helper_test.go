@@ -356,3 +356,69 @@ func TestHelper_PrintStringOnScreen_function(t *testing.T) {
 		t.Error(e)
 	}
 }
+
+func TestHelper_Union(t *testing.T) {
+	r1 := Rect{X: 1, Y: 2, Width: 3, Height: 4}
+	r2 := Rect{X: 5, Y: 0, Width: 2, Height: 2}
+
+	result := Rect{X: 1, Y: 0, Width: 6, Height: 6}
+
+	if r := Union(r1, r2); r != result {
+		t.Errorf("Should be return %+v, return %+v", result, r)
+	}
+
+	if r := Union(Rect{}, r2); r != r2 {
+		t.Errorf("Should be return %+v, return %+v", r2, r)
+	}
+}
+
+func TestHelper_Contains(t *testing.T) {
+	r1 := Rect{X: 1, Y: 1, Width: 10, Height: 10}
+
+	if !Contains(r1, Rect{X: 1, Y: 1, Width: 10, Height: 10}) {
+		t.Error("Rectangle must contain itself")
+	}
+
+	if Contains(r1, Rect{X: 5, Y: 5, Width: 10, Height: 1}) {
+		t.Error("Rectangle must not contain partial rectangle")
+	}
+}
+
+func TestHelper_ViewAt(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := NewMemoryCanvas(20, 20)
+
+	parent := NewView("parent", appConfig.Message, m)
+	parent.SetBounds(Rect{X: 2, Y: 2, Width: 10, Height: 10})
+	parent.SetVisible(true)
+
+	child := NewView("child", appConfig.Message, parent.ClientCanvas())
+	child.SetBounds(Rect{X: 8, Y: 0, Width: 5, Height: 2})
+	child.SetVisible(true)
+	child.SetParent(&parent)
+	parent.AddChild(&child)
+
+	hidden := NewView("hidden", appConfig.Message, parent.ClientCanvas())
+	hidden.SetBounds(Rect{X: 0, Y: 5, Width: 5, Height: 2})
+	hidden.SetParent(&parent)
+	parent.AddChild(&hidden)
+
+	tests := []struct {
+		x, y int
+		view TView
+	}{
+		{10, 2, &child},
+		{2, 7, &parent},
+		{13, 2, nil},
+		{1, 1, nil},
+	}
+
+	for _, test := range tests {
+		v := ViewAt(&parent, test.x, test.y)
+
+		if (v == nil) != (test.view == nil) || (v != nil && v.Handler() != test.view.Handler()) {
+			t.Errorf("Wrong view at (%d, %d): %v", test.x, test.y, v)
+		}
+	}
+}
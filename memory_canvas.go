@@ -0,0 +1,213 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// Cell is a character and his style.
+type Cell struct {
+	Char rune
+	// Combining runes (accent...).
+	Combining []rune
+	Style     tcell.Style
+}
+
+// MemoryCanvas is an off-screen canvas. Views can draw in it without
+// tcell.Screen (double buffer, cache, unit test...).
+type MemoryCanvas struct {
+	// Brush styte
+	brush tcell.Style
+	// Size of canvas.
+	width  int
+	height int
+	// Cells, line by line.
+	cells []Cell
+}
+
+// SetBrush set the brush to draw.
+func (m *MemoryCanvas) SetBrush(b tcell.Style) {
+	m.brush = b
+}
+
+// UpdateBounds resize canvas. Position is ignored.
+func (m *MemoryCanvas) UpdateBounds(r Rect) {
+	m.Resize(r.Width, r.Height)
+}
+
+// CreateCanvasFrom create a sub-canvas for `r` parameter.
+func (m *MemoryCanvas) CreateCanvasFrom(r Rect) TCanvas {
+	return NewCanvas(m, r)
+}
+
+// PrintChar print a charactere.
+func (m *MemoryCanvas) PrintChar(x int, y int, char rune) {
+	m.PrintCharWithBrush(x, y, char, m.brush)
+}
+
+// PrintCharWithBrush print a charactere with brush.
+func (m *MemoryCanvas) PrintCharWithBrush(x int, y int, char rune, brush tcell.Style) {
+	m.PrintCellWithBrush(x, y, char, nil, brush)
+}
+
+// PrintCellWithBrush print a charactere and his combining runes with brush.
+func (m *MemoryCanvas) PrintCellWithBrush(x int, y int, char rune, combc []rune, brush tcell.Style) {
+	if m.contains(x, y) {
+		m.cells[y*m.width+x] = Cell{
+			Char:      char,
+			Combining: combc,
+			Style:     brush,
+		}
+	}
+}
+
+// PrintString print a string with current brush. Return width in cells.
+func (m *MemoryCanvas) PrintString(x int, y int, text string) int {
+	return printString(m, x, y, text, m.brush)
+}
+
+// PrintStringWithBrush print a string with brush. Return width in cells.
+func (m *MemoryCanvas) PrintStringWithBrush(x int, y int, text string, brush tcell.Style) int {
+	return printString(m, x, y, text, brush)
+}
+
+// MeasureString return width in cells of string.
+func (m *MemoryCanvas) MeasureString(text string) int {
+	return MeasureString(text)
+}
+
+// DrawHLine draw horizontal line with current brush.
+func (m *MemoryCanvas) DrawHLine(x int, y int, length int, style LineStyle) {
+	drawHLine(m, x, y, length, style, m.brush)
+}
+
+// DrawVLine draw vertical line with current brush.
+func (m *MemoryCanvas) DrawVLine(x int, y int, length int, style LineStyle) {
+	drawVLine(m, x, y, length, style, m.brush)
+}
+
+// DrawRect draw rectangle border with current brush.
+func (m *MemoryCanvas) DrawRect(r Rect, style LineStyle) {
+	drawRect(m, r, style, m.brush)
+}
+
+// DrawFrame draw rectangle border and fill inside with current brush.
+func (m *MemoryCanvas) DrawFrame(r Rect, style LineStyle) {
+	drawFrame(m, r, style, m.brush)
+}
+
+// Fill fill canvas zone.
+func (m *MemoryCanvas) Fill(bounds Rect) {
+	for y := bounds.Y; y < bounds.Y+bounds.Height; y++ {
+		for x := bounds.X; x < bounds.X+bounds.Width; x++ {
+			m.PrintChar(x, y, ' ')
+		}
+	}
+}
+
+// Size return width and height of canvas.
+func (m *MemoryCanvas) Size() (int, int) {
+	return m.width, m.height
+}
+
+// Resize change size of canvas. Content in new size is kept.
+func (m *MemoryCanvas) Resize(width int, height int) {
+	width = MaxInt(width, 0)
+	height = MaxInt(height, 0)
+
+	if width == m.width && height == m.height {
+		return
+	}
+
+	cells := newCells(width, height)
+
+	for y := 0; y < MinInt(height, m.height); y++ {
+		copy(cells[y*width:y*width+MinInt(width, m.width)], m.cells[y*m.width:])
+	}
+
+	m.width = width
+	m.height = height
+	m.cells = cells
+}
+
+// GetCell return cell at position. If out of canvas, return empty cell.
+func (m *MemoryCanvas) GetCell(x int, y int) Cell {
+	if !m.contains(x, y) {
+		return Cell{
+			Char:  ' ',
+			Style: tcell.StyleDefault,
+		}
+	}
+
+	return m.cells[y*m.width+x]
+}
+
+// Clear fill canvas with space and default style.
+func (m *MemoryCanvas) Clear() {
+	m.cells = newCells(m.width, m.height)
+}
+
+// BlitTo copy `src` area of canvas to `parent` canvas at position x, y.
+func (m *MemoryCanvas) BlitTo(parent TCanvas, src Rect, x int, y int) {
+	src = Intersect(src, Rect{
+		X:      0,
+		Y:      0,
+		Width:  m.width,
+		Height: m.height,
+	})
+
+	for row := 0; row < src.Height; row++ {
+		for col := 0; col < src.Width; col++ {
+			cell := m.cells[(src.Y+row)*m.width+src.X+col]
+
+			parent.PrintCellWithBrush(x+col, y+row, cell.Char, cell.Combining, cell.Style)
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func (m *MemoryCanvas) contains(x int, y int) bool {
+	return x >= 0 && y >= 0 && x < m.width && y < m.height
+}
+
+func newCells(width int, height int) []Cell {
+	cells := make([]Cell, width*height)
+
+	for i := range cells {
+		cells[i] = Cell{
+			Char:  ' ',
+			Style: tcell.StyleDefault,
+		}
+	}
+
+	return cells
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewMemoryCanvas create an off-screen canvas.
+func NewMemoryCanvas(width int, height int) *MemoryCanvas {
+	m := &MemoryCanvas{
+		brush: tcell.StyleDefault,
+	}
+
+	m.Resize(width, height)
+
+	return m
+}
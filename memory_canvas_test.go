@@ -0,0 +1,111 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func checkMemoryCell(m *MemoryCanvas, x int, y int, c rune, st tcell.Style, t *testing.T) {
+	cell := m.GetCell(x, y)
+
+	if cell.Char != c {
+		t.Errorf("Incorrect cell content at (x: %d, y: %d). Want '%c': Found '%c'", x, y, c, cell.Char)
+	} else if cell.Style != st {
+		t.Errorf("Incorrect style at (x: %d, y: %d)", x, y)
+	}
+}
+
+func TestMemoryCanvas_draw_view(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	m := NewMemoryCanvas(10, 5)
+
+	v := NewView("view", appConfig.Message, m)
+	v.SetBounds(Rect{
+		X:      2,
+		Y:      1,
+		Width:  3,
+		Height: 2,
+	})
+	v.SetForegroundColor(tcell.ColorYellow)
+	v.SetBackgroundColor(tcell.ColorBlue)
+	v.SetVisible(true)
+
+	v.Draw()
+
+	st := tcell.StyleDefault.
+		Foreground(tcell.ColorYellow).
+		Background(tcell.ColorBlue)
+
+	checkMemoryCell(m, 2, 1, ' ', st, t)
+	checkMemoryCell(m, 4, 2, ' ', st, t)
+	checkMemoryCell(m, 5, 2, ' ', tcell.StyleDefault, t)
+	checkMemoryCell(m, 1, 1, ' ', tcell.StyleDefault, t)
+
+	// Out of canvas.
+	m.PrintChar(10, 0, 'X')
+	checkMemoryCell(m, 10, 0, ' ', tcell.StyleDefault, t)
+}
+
+func TestMemoryCanvas_resize(t *testing.T) {
+	m := NewMemoryCanvas(3, 3)
+	m.PrintChar(0, 0, 'A')
+	m.PrintChar(2, 2, 'B')
+
+	m.Resize(5, 2)
+
+	if w, h := m.Size(); w != 5 || h != 2 {
+		t.Errorf("Wrong size %d x %d", w, h)
+	}
+
+	checkMemoryCell(m, 0, 0, 'A', tcell.StyleDefault, t)
+	checkMemoryCell(m, 2, 2, ' ', tcell.StyleDefault, t)
+	checkMemoryCell(m, 4, 1, ' ', tcell.StyleDefault, t)
+
+	m.UpdateBounds(Rect{X: 5, Y: 5, Width: 1, Height: 1})
+
+	checkMemoryCell(m, 0, 0, 'A', tcell.StyleDefault, t)
+
+	m.Clear()
+
+	checkMemoryCell(m, 0, 0, ' ', tcell.StyleDefault, t)
+}
+
+func TestMemoryCanvas_BlitTo(t *testing.T) {
+	st := tcell.StyleDefault.Foreground(tcell.ColorRed)
+
+	m := NewMemoryCanvas(4, 4)
+	m.SetBrush(st)
+	m.Fill(Rect{X: 1, Y: 1, Width: 2, Height: 2})
+	m.PrintChar(1, 1, '#')
+
+	parent := NewMemoryCanvas(10, 10)
+
+	// Area out of canvas is ignored.
+	m.BlitTo(parent, Rect{X: 1, Y: 1, Width: 10, Height: 10}, 5, 6)
+
+	checkMemoryCell(parent, 5, 6, '#', st, t)
+	checkMemoryCell(parent, 6, 7, ' ', st, t)
+	checkMemoryCell(parent, 7, 8, ' ', tcell.StyleDefault, t)
+	checkMemoryCell(parent, 4, 6, ' ', tcell.StyleDefault, t)
+
+	sub := m.CreateCanvasFrom(Rect{X: 1, Y: 1, Width: 2, Height: 2})
+	sub.PrintCharWithBrush(1, 0, '!', st)
+
+	checkMemoryCell(m, 2, 1, '!', st, t)
+}
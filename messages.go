@@ -87,6 +87,61 @@ const WmMouseEnter uint = 17
 // WmMouseLeave sent when mouse leave to TView.
 const WmMouseLeave uint = 18
 
+// WmInvalidate sent to Application when a screen area (absolute coordinates)
+// must be repaint.
+const WmInvalidate uint = 19
+
+// WmThemeChanged broadcast when theme of application change. Value is new
+// *Theme.
+const WmThemeChanged uint = 20
+
+// WmStylesheetChanged sent to Application when stylesheet file is reloaded.
+// Value is new *Stylesheet.
+const WmStylesheetChanged uint = 21
+
+// WmMouseWheel sent to view under mouse when wheel turn. Value is
+// *tcell.EventMouse. If view does not manage it, message is sent to parent.
+const WmMouseWheel uint = 22
+
+// WmScroll sent to scrollable view to change scroll position. Value is Rect
+// with new position in X and Y.
+const WmScroll uint = 23
+
+// WmAnimationFrame sent to Application at each animation frame.
+const WmAnimationFrame uint = 24
+
+// WmMouseDrag sent to view that received button down when mouse moves with
+// button pressed. Value is *tcell.EventMouse.
+const WmMouseDrag uint = 25
+
+// WmShowCursor sent to Application to show text cursor. Value is Caret.
+const WmShowCursor uint = 26
+
+// WmHideCursor sent to Application to hide text cursor. Value is handler of
+// view that shows cursor. Cursor is hidden only if view still owns it.
+const WmHideCursor uint = 27
+
+// WmSetClipboard sent to Application to change clipboard. Value is text.
+const WmSetClipboard uint = 28
+
+// WmGetClipboard sent to Application to read clipboard. Value is handler of
+// view that receives WmPaste.
+const WmGetClipboard uint = 29
+
+// WmPaste sent by Application in answer to WmGetClipboard. Value is text of
+// clipboard.
+const WmPaste uint = 30
+
+// WmLButtonDblClick sent to window beneath cursor after second WmLButtonDown
+// of a double click. Value is *tcell.EventMouse.
+const WmLButtonDblClick uint = 31
+
+// WmClosePopup sent to popup when mouse button is pressed outside of it.
+const WmClosePopup uint = 32
+
+// WmCommand broadcast when user choose a menu item. Value is command (uint).
+const WmCommand uint = 33
+
 // WmUser allow user to have own message.
 const WmUser uint = ^uint(0) / 2
 
@@ -96,6 +151,13 @@ const WaInactive uint = 0
 // WaActive Activated.
 const WaActive uint = 1
 
+// Caret is position (absolute coordinates) of text cursor shows by a view.
+type Caret struct {
+	Owner uuid.UUID
+	X     int
+	Y     int
+}
+
 // BuildKeyMessage build a message for keyboard event.
 func BuildKeyMessage(event *tcell.EventKey) Message {
 	return Message{
@@ -105,6 +167,15 @@ func BuildKeyMessage(event *tcell.EventKey) Message {
 	}
 }
 
+// BuildMouseMessage build a message for mouse event.
+func BuildMouseMessage(event *tcell.EventMouse) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmMouse,
+		Value:   event,
+	}
+}
+
 // BuildScreenResizeMessage build a resize message broadcast.
 func BuildScreenResizeMessage(screen tcell.Screen) Message {
 	width, height := screen.Size()
@@ -202,3 +273,127 @@ func BuildMouseLeaveMessage(handler uuid.UUID) Message {
 		Type:    WmMouseLeave,
 	}
 }
+
+// BuildInvalidateMessage return a message to repaint area `r` of screen.
+func BuildInvalidateMessage(r Rect) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmInvalidate,
+		Value:   r,
+	}
+}
+
+// BuildThemeChangedMessage return a broadcast message with new theme.
+func BuildThemeChangedMessage(t *Theme) Message {
+	return Message{
+		Handler: BroadcastHandler(),
+		Type:    WmThemeChanged,
+		Value:   t,
+	}
+}
+
+// BuildMouseWheelMessage return a message for wheel event on view.
+func BuildMouseWheelMessage(handler uuid.UUID, ev *tcell.EventMouse) Message {
+	return Message{
+		Handler: handler,
+		Type:    WmMouseWheel,
+		Value:   ev,
+	}
+}
+
+// BuildScrollMessage return a message to scroll view at position (x, y).
+func BuildScrollMessage(handler uuid.UUID, x, y int) Message {
+	return Message{
+		Handler: handler,
+		Type:    WmScroll,
+		Value: Rect{
+			X: x,
+			Y: y,
+		},
+	}
+}
+
+// BuildAnimationFrameMessage return a message to animate next frame.
+func BuildAnimationFrameMessage() Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmAnimationFrame,
+	}
+}
+
+// BuildClosePopupMessage return a message to close popup.
+func BuildClosePopupMessage(handler uuid.UUID) Message {
+	return Message{
+		Handler: handler,
+		Type:    WmClosePopup,
+	}
+}
+
+// BuildCommandMessage return a message to broadcast a command.
+func BuildCommandMessage(command uint) Message {
+	return Message{
+		Handler: BroadcastHandler(),
+		Type:    WmCommand,
+		Value:   command,
+	}
+}
+
+// BuildMouseDragMessage return a message for mouse move with button pressed.
+func BuildMouseDragMessage(handler uuid.UUID, ev *tcell.EventMouse) Message {
+	return Message{
+		Handler: handler,
+		Type:    WmMouseDrag,
+		Value:   ev,
+	}
+}
+
+// BuildShowCursorMessage return a message to show text cursor of view `owner`
+// at (x, y) of screen.
+func BuildShowCursorMessage(owner uuid.UUID, x, y int) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmShowCursor,
+		Value: Caret{
+			Owner: owner,
+			X:     x,
+			Y:     y,
+		},
+	}
+}
+
+// BuildHideCursorMessage return a message to hide text cursor of view `owner`.
+func BuildHideCursorMessage(owner uuid.UUID) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmHideCursor,
+		Value:   owner,
+	}
+}
+
+// BuildSetClipboardMessage return a message to put text in clipboard.
+func BuildSetClipboardMessage(text string) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmSetClipboard,
+		Value:   text,
+	}
+}
+
+// BuildGetClipboardMessage return a message to ask clipboard. Application
+// answers with WmPaste to `handler`.
+func BuildGetClipboardMessage(handler uuid.UUID) Message {
+	return Message{
+		Handler: ApplicationHandler(),
+		Type:    WmGetClipboard,
+		Value:   handler,
+	}
+}
+
+// BuildPasteMessage return a message with clipboard text.
+func BuildPasteMessage(handler uuid.UUID, text string) Message {
+	return Message{
+		Handler: handler,
+		Type:    WmPaste,
+		Value:   text,
+	}
+}
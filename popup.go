@@ -0,0 +1,76 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// TPopup is a top-level view draw above all windows (drop-down list, menu).
+// It is never activated: clicks are sent without activation, keys are
+// managed by its owner. WmClosePopup is sent when mouse is pressed outside.
+type TPopup interface {
+	// GetOwner return view that opened popup. Click on owner does not close
+	// popup.
+	GetOwner() TView
+}
+
+// TKeyPreview is a popup that receives keys before active window (e.g. menu
+// bar for F10 and Alt+letter).
+type TKeyPreview interface {
+	// PreviewKey return true if key is managed and must not be sent to
+	// active window.
+	PreviewKey(ev *tcell.EventKey) bool
+}
+
+// Layers of top-level windows, from bottom to top.
+const (
+	layerNormal = iota
+	layerStayOnTop
+	layerPopup
+)
+
+// Return layer of top-level window.
+func layerOf(w TView) int {
+	if _, ok := w.(TPopup); ok {
+		return layerPopup
+	}
+
+	if w.GetStayOnTop() {
+		return layerStayOnTop
+	}
+
+	return layerNormal
+}
+
+// Return true if top-level window `w` is `p` or a popup opened by `p` (or by
+// a popup opened by `p`...).
+func openedBy(w TView, p TView) bool {
+	for w != nil {
+		if w.Handler() == p.Handler() {
+			return true
+		}
+
+		popup, ok := w.(TPopup)
+
+		if !ok {
+			return false
+		}
+
+		w = popup.GetOwner()
+	}
+
+	return false
+}
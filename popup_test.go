@@ -0,0 +1,156 @@
+package base_test
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+import (
+	"testing"
+
+	base "github.com/emeric-martineau/govision"
+	"github.com/emeric-martineau/govision/govisiontest"
+	"github.com/gdamore/tcell"
+)
+
+// Popup is opened by owner, a child of window. Window is active.
+func createPopupTestHarness(t *testing.T) (*govisiontest.Harness, *base.View, *testPopup) {
+	h := govisiontest.NewHarness(t, 80, 25)
+
+	windows := addTestWindows(h, "palette", "window")
+
+	palette := windows[0]
+	palette.SetStayOnTop(true)
+	palette.SetBounds(base.Rect{X: 30, Y: 0, Width: 10, Height: 10})
+
+	window := windows[1]
+	window.SetBounds(base.Rect{X: 0, Y: 0, Width: 20, Height: 10})
+
+	owner := base.NewView("owner", h.Config.Message, window.ClientCanvas())
+	owner.SetEnabled(true)
+	owner.SetVisible(true)
+	owner.SetBounds(base.Rect{X: 2, Y: 2, Width: 5, Height: 1})
+	owner.SetParent(window)
+	window.AddChild(&owner)
+
+	popup := &testPopup{
+		owner: &owner,
+		View:  base.NewView("popup", h.Config.Message, h.App.Canvas()),
+	}
+	popup.SetEnabled(true)
+	popup.SetVisible(true)
+	popup.SetBounds(base.Rect{X: 2, Y: 3, Width: 5, Height: 3})
+
+	h.App.AddWindow(popup)
+
+	h.Start()
+
+	// Activate window.
+	h.Click(15, 8)
+
+	return h, window, popup
+}
+
+func TestPopup_layer(t *testing.T) {
+	h, window, popup := createPopupTestHarness(t)
+	defer h.Close()
+
+	checkWindowsOrder(h, []string{"popup", "palette", "window"}, t)
+
+	h.App.BringToFront(window)
+
+	checkWindowsOrder(h, []string{"popup", "palette", "window"}, t)
+
+	if h.App.ActiveWindow() != window {
+		t.Error("Popup can't be activated")
+	}
+
+	// Popup is in front when application starts.
+	for _, w := range h.App.ActivationHistory() {
+		if w.Handler() == popup.Handler() {
+			t.Error("Popup must not be in activation history")
+		}
+	}
+}
+
+func TestPopup_click(t *testing.T) {
+	h, window, popup := createPopupTestHarness(t)
+	defer h.Close()
+
+	messages := countMessages(popup)
+
+	h.Click(3, 4)
+
+	if h.App.ActiveWindow() != window {
+		t.Error("Click on popup must not activate it")
+	}
+
+	if messages[base.WmLButtonDown] != 1 {
+		t.Errorf("Click must be sent to popup. Found %+v", messages)
+	}
+
+	// Click on owner.
+	h.Click(3, 2)
+
+	if messages[base.WmClosePopup] != 0 {
+		t.Errorf("Click on owner must not close popup. Found %+v", messages)
+	}
+
+	h.Click(15, 8)
+
+	if messages[base.WmClosePopup] != 1 {
+		t.Errorf("Click outside must close popup. Found %+v", messages)
+	}
+}
+
+func TestPopup_click_sub_popup(t *testing.T) {
+	h, _, popup := createPopupTestHarness(t)
+	defer h.Close()
+
+	sub := &testPopup{
+		owner: popup,
+		View:  base.NewView("sub", h.Config.Message, h.App.Canvas()),
+	}
+	sub.SetEnabled(true)
+	sub.SetVisible(true)
+	sub.SetBounds(base.Rect{X: 7, Y: 4, Width: 5, Height: 3})
+
+	h.App.AddWindow(sub)
+
+	popupMessages := countMessages(popup)
+	subMessages := countMessages(sub)
+
+	h.Click(8, 5)
+
+	if popupMessages[base.WmClosePopup]+subMessages[base.WmClosePopup] != 0 {
+		t.Error("Click in sub-popup must not close popup")
+	}
+
+	h.Click(15, 8)
+
+	if popupMessages[base.WmClosePopup] != 1 || subMessages[base.WmClosePopup] != 1 {
+		t.Errorf("Click outside must close popup and sub-popup. Found %+v, %+v", popupMessages, subMessages)
+	}
+}
+
+func TestPopup_preview_key(t *testing.T) {
+	h, window, popup := createPopupTestHarness(t)
+	defer h.Close()
+
+	messages := countMessages(window)
+
+	h.Key(tcell.KeyF10, 0, tcell.ModNone)
+	h.Key(tcell.KeyF9, 0, tcell.ModNone)
+
+	if popup.keys != 1 || messages[base.WmKey] != 1 {
+		t.Errorf("Popup must manage F10 and window F9. Found %d, %d", popup.keys, messages[base.WmKey])
+	}
+}
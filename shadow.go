@@ -0,0 +1,80 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// ShadowStyle is style of cells under a shadow.
+var ShadowStyle = tcell.StyleDefault.
+	Foreground(tcell.ColorGray).
+	Background(tcell.ColorBlack)
+
+// TShadow is a view that cast a shadow one cell right and one row down.
+type TShadow interface {
+	// GetShadow return true if view cast a shadow.
+	GetShadow() bool
+}
+
+// ShadowBounds return bounds `r` with shadow.
+func ShadowBounds(r Rect) Rect {
+	r.Width++
+	r.Height++
+
+	return r
+}
+
+// PaintBounds return area draw by view, shadow included.
+func PaintBounds(v TView) Rect {
+	if s, ok := v.(TShadow); ok && s.GetShadow() {
+		return ShadowBounds(v.GetBounds())
+	}
+
+	return v.GetBounds()
+}
+
+// DrawShadow dim cells on right and bottom of `r` with ShadowStyle.
+// Characters are kept, only style change.
+func DrawShadow(c TCanvas, r Rect) {
+	DrawShadowWithBrush(c, r, ShadowStyle)
+}
+
+// DrawShadowWithBrush dim cells on right and bottom of `r` with brush.
+func DrawShadowWithBrush(c TCanvas, r Rect, brush tcell.Style) {
+	if IsEmpty(r) {
+		return
+	}
+
+	right := r.X + r.Width
+	bottom := r.Y + r.Height
+
+	for y := r.Y + 1; y <= bottom; y++ {
+		dimCell(c, right, y, brush)
+	}
+
+	for x := r.X + 1; x < right; x++ {
+		dimCell(c, x, bottom, brush)
+	}
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func dimCell(c TCanvas, x int, y int, brush tcell.Style) {
+	cell := c.GetCell(x, y)
+
+	c.PrintCellWithBrush(x, y, cell.Char, cell.Combining, brush)
+}
@@ -0,0 +1,111 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+type shadowView struct {
+	View
+}
+
+func (s *shadowView) GetShadow() bool {
+	return true
+}
+
+func TestShadow_DrawShadow(t *testing.T) {
+	st := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+
+	m := NewMemoryCanvas(6, 5)
+	m.SetBrush(st)
+	m.PrintString(0, 2, "ABCDEF")
+	m.PrintString(0, 3, "GHIJKL")
+
+	DrawShadow(m, Rect{X: 1, Y: 0, Width: 3, Height: 3})
+
+	// Right column.
+	checkMemoryCell(m, 4, 0, ' ', tcell.StyleDefault, t)
+	checkMemoryCell(m, 4, 1, ' ', ShadowStyle, t)
+	checkMemoryCell(m, 4, 2, 'E', ShadowStyle, t)
+	checkMemoryCell(m, 4, 3, 'K', ShadowStyle, t)
+	checkMemoryCell(m, 4, 4, ' ', tcell.StyleDefault, t)
+
+	// Bottom row.
+	checkMemoryCell(m, 1, 3, 'H', st, t)
+	checkMemoryCell(m, 2, 3, 'I', ShadowStyle, t)
+	checkMemoryCell(m, 3, 3, 'J', ShadowStyle, t)
+	checkMemoryCell(m, 5, 3, 'L', st, t)
+}
+
+func TestShadow_PaintBounds(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	r := Rect{X: 1, Y: 2, Width: 3, Height: 4}
+
+	v := NewView("view", appConfig.Message, NewMemoryCanvas(10, 10))
+	v.SetBounds(r)
+
+	if b := PaintBounds(&v); b != r {
+		t.Errorf("View without shadow must paint only in bounds. Found %+v", b)
+	}
+
+	s := shadowView{
+		View: NewView("shadow", appConfig.Message, NewMemoryCanvas(10, 10)),
+	}
+	s.SetBounds(r)
+
+	if b := PaintBounds(&s); b != (Rect{X: 1, Y: 2, Width: 4, Height: 5}) {
+		t.Errorf("View with shadow must paint shadow. Found %+v", b)
+	}
+}
+
+func TestShadow_repaint_shadow_region(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	s := shadowView{
+		View: NewView("shadow", appConfig.Message, app.Canvas()),
+	}
+	s.SetVisible(true)
+	s.SetBounds(Rect{X: 0, Y: 0, Width: 5, Height: 5})
+	s.SetOnDraw(func(v TView) {
+		v.Draw()
+		DrawShadow(app.Canvas(), v.GetBounds())
+	})
+
+	app.AddWindow(&s)
+
+	if e := app.Init(); e != nil {
+		t.Error("Cannot initialize screen")
+	}
+
+	defer appConfig.Screen.Fini()
+
+	appConfig.Screen.Clear()
+
+	// Region only on shadow.
+	app.addInvalidRegion(Rect{X: 5, Y: 1, Width: 1, Height: 5})
+	app.repaintInvalidRegions()
+
+	appConfig.Screen.Show()
+
+	if e := checkCell(appConfig.Screen, 5, 2, DefaultDesktopPattern, ShadowStyle, t); e != nil {
+		t.Error(e)
+	}
+}
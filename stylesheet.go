@@ -0,0 +1,528 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell"
+)
+
+// Stylesheet is a list of rules to style components. Format is like CSS:
+//
+//	/* Comment */
+//	Window { bg: navy; fg: white; border: double; }
+//	Window#editor:focused, Desktop { attr: bold underline; }
+//	Window > View:hover { fg: yellow; }
+//
+// Selector match type (Go struct name or *), Name() after #, ancestry
+// (space for descendant, > for child) and state (:focused, :disabled,
+// :hover). Properties are fg, bg, attr (bold, dim, underline, blink,
+// reverse, none) and border (single, double, heavy, rounded, ascii, blank).
+// Most specific rule win, then last rule.
+type Stylesheet struct {
+	rules []styleRule
+}
+
+// StyleProperties is properties set by stylesheet. Has* is true if property
+// is set.
+type StyleProperties struct {
+	Foreground    tcell.Color
+	HasForeground bool
+	Background    tcell.Color
+	HasBackground bool
+	Attributes    tcell.AttrMask
+	HasAttributes bool
+	Border        LineStyle
+	HasBorder     bool
+}
+
+// Apply properties to style.
+func (p StyleProperties) Apply(st tcell.Style) tcell.Style {
+	if p.HasForeground {
+		st = st.Foreground(p.Foreground)
+	}
+
+	if p.HasBackground {
+		st = st.Background(p.Background)
+	}
+
+	if p.HasAttributes {
+		fg, bg, _ := st.Decompose()
+		st = tcell.StyleDefault.Foreground(fg).Background(bg)
+
+		for _, a := range []tcell.AttrMask{tcell.AttrBold, tcell.AttrDim, tcell.AttrUnderline,
+			tcell.AttrBlink, tcell.AttrReverse} {
+			if p.Attributes&a != 0 {
+				st = setAttribute(st, a)
+			}
+		}
+	}
+
+	return st
+}
+
+// Properties return properties of rules that match component.
+func (s *Stylesheet) Properties(c TComponent) StyleProperties {
+	var p StyleProperties
+
+	for _, r := range s.rules {
+		if !r.match(c) {
+			continue
+		}
+
+		p.merge(r.properties)
+	}
+
+	return p
+}
+
+// ParseStylesheet read stylesheet.
+func ParseStylesheet(r io.Reader) (*Stylesheet, error) {
+	data, e := ioutil.ReadAll(r)
+
+	if e != nil {
+		return nil, e
+	}
+
+	text := removeComments(string(data))
+	s := &Stylesheet{}
+	line := 1
+
+	for {
+		open := strings.Index(text, "{")
+
+		if open < 0 {
+			if rest := strings.TrimSpace(text); rest != "" {
+				line += strings.Count(text[:strings.Index(text, rest)], "\n")
+
+				return nil, fmt.Errorf("stylesheet line %d: '{' expected", line)
+			}
+
+			break
+		}
+
+		end := strings.Index(text, "}")
+
+		if end < open {
+			return nil, fmt.Errorf("stylesheet line %d: '}' expected", line)
+		}
+
+		selectors := text[:open]
+		declarations := text[open+1 : end]
+
+		if e := s.addRules(selectors, declarations, line+strings.Count(selectors, "\n")); e != nil {
+			return nil, e
+		}
+
+		line += strings.Count(text[:end+1], "\n")
+		text = text[end+1:]
+	}
+
+	// Most specific last, keep order of file for same specificity.
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		return s.rules[i].specificity < s.rules[j].specificity
+	})
+
+	return s, nil
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// State of component in selector.
+const (
+	stateFocused  = "focused"
+	stateDisabled = "disabled"
+	stateHover    = "hover"
+)
+
+type styleRule struct {
+	// Selector parts from ancestor to component.
+	selector    []selectorPart
+	properties  StyleProperties
+	specificity int
+}
+
+type selectorPart struct {
+	// Struct name, empty for any.
+	typeName string
+	// Name of component, empty for any.
+	name   string
+	states []string
+	// Previous part must be parent (not only ancestor).
+	child bool
+}
+
+// View that know if mouse is over it.
+type hoverView interface {
+	GetHover() bool
+}
+
+func (p *StyleProperties) merge(o StyleProperties) {
+	if o.HasForeground {
+		p.Foreground = o.Foreground
+		p.HasForeground = true
+	}
+
+	if o.HasBackground {
+		p.Background = o.Background
+		p.HasBackground = true
+	}
+
+	if o.HasAttributes {
+		p.Attributes = o.Attributes
+		p.HasAttributes = true
+	}
+
+	if o.HasBorder {
+		p.Border = o.Border
+		p.HasBorder = true
+	}
+}
+
+func setAttribute(st tcell.Style, a tcell.AttrMask) tcell.Style {
+	switch a {
+	case tcell.AttrBold:
+		return st.Bold(true)
+	case tcell.AttrDim:
+		return st.Dim(true)
+	case tcell.AttrUnderline:
+		return st.Underline(true)
+	case tcell.AttrBlink:
+		return st.Blink(true)
+	case tcell.AttrReverse:
+		return st.Reverse(true)
+	}
+
+	return st
+}
+
+// Replace comments by spaces, new lines are kept for line numbers.
+func removeComments(text string) string {
+	var b strings.Builder
+
+	for {
+		start := strings.Index(text, "/*")
+
+		if start < 0 {
+			b.WriteString(text)
+
+			return b.String()
+		}
+
+		end := strings.Index(text[start+2:], "*/")
+
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start + 4
+		}
+
+		b.WriteString(text[:start])
+		b.WriteString(strings.Repeat("\n", strings.Count(text[start:end], "\n")))
+
+		text = text[end:]
+	}
+}
+
+func (s *Stylesheet) addRules(selectors string, declarations string, line int) error {
+	properties, e := parseDeclarations(declarations)
+
+	if e != nil {
+		return fmt.Errorf("stylesheet line %d: %s", line, e)
+	}
+
+	for _, sel := range strings.Split(selectors, ",") {
+		parts, e := parseSelector(sel)
+
+		if e != nil {
+			return fmt.Errorf("stylesheet line %d: %s", line, e)
+		}
+
+		specificity := 0
+
+		for _, p := range parts {
+			if p.name != "" {
+				specificity += 100
+			}
+
+			specificity += 10 * len(p.states)
+
+			if p.typeName != "" {
+				specificity++
+			}
+		}
+
+		s.rules = append(s.rules, styleRule{
+			selector:    parts,
+			properties:  properties,
+			specificity: specificity,
+		})
+	}
+
+	return nil
+}
+
+func parseSelector(sel string) ([]selectorPart, error) {
+	fields := strings.Fields(strings.Replace(sel, ">", " > ", -1))
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+
+	parts := make([]selectorPart, 0, len(fields))
+	child := false
+
+	for _, f := range fields {
+		if f == ">" {
+			if len(parts) == 0 || child {
+				return nil, fmt.Errorf("misplaced '>' in '%s'", strings.TrimSpace(sel))
+			}
+
+			child = true
+
+			continue
+		}
+
+		p, e := parseSelectorPart(f)
+
+		if e != nil {
+			return nil, e
+		}
+
+		p.child = child
+		child = false
+		parts = append(parts, p)
+	}
+
+	if child {
+		return nil, fmt.Errorf("misplaced '>' in '%s'", strings.TrimSpace(sel))
+	}
+
+	return parts, nil
+}
+
+// Parse Type#name:state:state.
+func parseSelectorPart(text string) (selectorPart, error) {
+	var p selectorPart
+
+	items := strings.Split(text, ":")
+
+	p.typeName = items[0]
+
+	if i := strings.Index(p.typeName, "#"); i >= 0 {
+		p.name = p.typeName[i+1:]
+		p.typeName = p.typeName[:i]
+
+		if p.name == "" {
+			return p, fmt.Errorf("empty name in '%s'", text)
+		}
+	}
+
+	if p.typeName == "*" {
+		p.typeName = ""
+	}
+
+	for _, state := range items[1:] {
+		switch state {
+		case stateFocused, stateDisabled, stateHover:
+			p.states = append(p.states, state)
+		default:
+			return p, fmt.Errorf("unknown state '%s'", state)
+		}
+	}
+
+	return p, nil
+}
+
+func parseDeclarations(text string) (StyleProperties, error) {
+	var p StyleProperties
+
+	for _, d := range strings.Split(text, ";") {
+		if strings.TrimSpace(d) == "" {
+			continue
+		}
+
+		i := strings.Index(d, ":")
+
+		if i < 0 {
+			return p, fmt.Errorf("':' expected in '%s'", strings.TrimSpace(d))
+		}
+
+		name := strings.TrimSpace(d[:i])
+		value := strings.ToLower(strings.TrimSpace(d[i+1:]))
+
+		var e error
+
+		switch name {
+		case "fg", "foreground":
+			p.Foreground, e = parseColor(value)
+			p.HasForeground = true
+		case "bg", "background":
+			p.Background, e = parseColor(value)
+			p.HasBackground = true
+		case "attr", "attributes":
+			p.Attributes, e = parseAttributes(value)
+			p.HasAttributes = true
+		case "border":
+			p.Border, e = parseBorder(value)
+			p.HasBorder = true
+		default:
+			e = fmt.Errorf("unknown property '%s'", name)
+		}
+
+		if e != nil {
+			return p, e
+		}
+	}
+
+	return p, nil
+}
+
+func parseColor(value string) (tcell.Color, error) {
+	if value == "default" {
+		return tcell.ColorDefault, nil
+	}
+
+	if c := tcell.GetColor(value); c != tcell.ColorDefault {
+		return c, nil
+	}
+
+	return tcell.ColorDefault, fmt.Errorf("unknown color '%s'", value)
+}
+
+func parseAttributes(value string) (tcell.AttrMask, error) {
+	attr := tcell.AttrNone
+
+	for _, a := range strings.Fields(value) {
+		switch a {
+		case "bold":
+			attr |= tcell.AttrBold
+		case "dim":
+			attr |= tcell.AttrDim
+		case "underline":
+			attr |= tcell.AttrUnderline
+		case "blink":
+			attr |= tcell.AttrBlink
+		case "reverse":
+			attr |= tcell.AttrReverse
+		case "none":
+		default:
+			return attr, fmt.Errorf("unknown attribute '%s'", a)
+		}
+	}
+
+	return attr, nil
+}
+
+func parseBorder(value string) (LineStyle, error) {
+	switch value {
+	case "single":
+		return LineStyleSingle, nil
+	case "double":
+		return LineStyleDouble, nil
+	case "heavy":
+		return LineStyleHeavy, nil
+	case "rounded":
+		return LineStyleRounded, nil
+	case "ascii":
+		return LineStyleASCII, nil
+	case "blank", "none":
+		return LineStyleBlank, nil
+	}
+
+	return LineStyleSingle, fmt.Errorf("unknown border '%s'", value)
+}
+
+// Struct name of component.
+func componentTypeName(c TComponent) string {
+	t := reflect.TypeOf(c)
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
+
+func (r styleRule) match(c TComponent) bool {
+	return matchSelector(r.selector, len(r.selector)-1, c)
+}
+
+// Check part `i` with component, then previous parts with ancestors.
+func matchSelector(parts []selectorPart, i int, c TComponent) bool {
+	if !parts[i].match(c) {
+		return false
+	}
+
+	if i == 0 {
+		return true
+	}
+
+	if parts[i].child {
+		p := c.GetParent()
+
+		return p != nil && matchSelector(parts, i-1, p)
+	}
+
+	for p := c.GetParent(); p != nil; p = p.GetParent() {
+		if matchSelector(parts, i-1, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p selectorPart) match(c TComponent) bool {
+	if p.typeName != "" && p.typeName != componentTypeName(c) {
+		return false
+	}
+
+	if p.name != "" && p.name != c.Name() {
+		return false
+	}
+
+	for _, state := range p.states {
+		if !hasState(c, state) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasState(c TComponent, state string) bool {
+	switch state {
+	case stateFocused:
+		v, ok := c.(TView)
+
+		return ok && v.GetFocused()
+	case stateDisabled:
+		return !c.GetEnabled()
+	case stateHover:
+		v, ok := c.(hoverView)
+
+		return ok && v.GetHover()
+	}
+
+	return false
+}
@@ -0,0 +1,242 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+func parseTestStylesheet(t *testing.T, text string) *Stylesheet {
+	s, e := ParseStylesheet(strings.NewReader(text))
+
+	if e != nil {
+		t.Fatalf("Cannot parse stylesheet: %s", e)
+	}
+
+	return s
+}
+
+func TestStylesheet_parse_errors(t *testing.T) {
+	tests := map[string]string{
+		"View { fg: white; ":                   "line 1: '}' expected",
+		"View { fg: white; }\n\n/* x\n */ Foo": "line 4: '{' expected",
+		"View {\n}\nView:active { fg: red; }":  "line 3: unknown state 'active'",
+		"View { color: red; }":                 "line 1: unknown property 'color'",
+		"View { fg: nocolor; }":                "line 1: unknown color 'nocolor'",
+		"View { attr: bold italic; }":          "line 1: unknown attribute 'italic'",
+		"View { border: dotted; }":             "line 1: unknown border 'dotted'",
+		"View > { fg: red; }":                  "line 1: misplaced '>' in 'View >'",
+		"View, { fg: red; }":                   "line 1: empty selector",
+		"View { fg red; }":                     "line 1: ':' expected in 'fg red'",
+		"#  { fg: red; }":                      "line 1: empty name in '#'",
+	}
+
+	for text, err := range tests {
+		if _, e := ParseStylesheet(strings.NewReader(text)); e == nil || e.Error() != "stylesheet "+err {
+			t.Errorf("Stylesheet '%s' must fail with '%s'. Found %v", text, err, e)
+		}
+	}
+}
+
+func TestStylesheet_Properties_specificity(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	s := parseTestStylesheet(t, `
+		/* Most specific win, even before. */
+		View#ok { fg: red; }
+		View { fg: white; bg: blue; border: double; }
+		* { fg: yellow; attr: bold underline; }
+		View { bg: #102030; }
+		View:focused { border: rounded; }
+	`)
+
+	ok := NewView("ok", appConfig.Message, NewMemoryCanvas(1, 1))
+	other := NewView("other", appConfig.Message, NewMemoryCanvas(1, 1))
+
+	p := s.Properties(&ok)
+
+	if !p.HasForeground || p.Foreground != tcell.ColorRed {
+		t.Errorf("Name selector must win. Found %+v", p)
+	}
+
+	if !p.HasBackground || p.Background != tcell.NewRGBColor(0x10, 0x20, 0x30) {
+		t.Errorf("Last rule must win. Found %+v", p)
+	}
+
+	if !p.HasAttributes || p.Attributes != tcell.AttrBold|tcell.AttrUnderline {
+		t.Errorf("Universal rule must set attributes. Found %+v", p)
+	}
+
+	if p = s.Properties(&other); p.Foreground != tcell.ColorWhite || p.Border != LineStyleDouble {
+		t.Errorf("Type rule must win on universal rule. Found %+v", p)
+	}
+
+	other.SetFocused(true)
+
+	if p = s.Properties(&other); p.Border != LineStyleRounded {
+		t.Errorf("State rule must apply on focused view. Found %+v", p)
+	}
+
+	st := p.Apply(tcell.StyleDefault.Foreground(tcell.ColorGreen).Reverse(true))
+
+	if st != tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(p.Background).Bold(true).Underline(true) {
+		t.Error("Apply must replace colors and attributes")
+	}
+}
+
+func TestStylesheet_Properties_ancestry_and_state(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	s := parseTestStylesheet(t, `
+		Desktop View { fg: red; }
+		Desktop > View { bg: blue; }
+		View:disabled:hover { attr: dim; }
+	`)
+
+	d := NewDesktop("desktop", appConfig.Message, NewMemoryCanvas(10, 10))
+
+	parent := NewView("parent", appConfig.Message, d.ClientCanvas())
+	parent.SetParent(&d)
+
+	child := NewView("child", appConfig.Message, parent.ClientCanvas())
+	child.SetParent(&parent)
+	child.SetBounds(Rect{X: 0, Y: 0, Width: 2, Height: 2})
+
+	if p := s.Properties(&parent); !p.HasForeground || !p.HasBackground {
+		t.Errorf("Child of desktop must match both rules. Found %+v", p)
+	}
+
+	if p := s.Properties(&child); !p.HasForeground || p.HasBackground {
+		t.Errorf("Grand child of desktop must match only descendant rule. Found %+v", p)
+	}
+
+	child.SetEnabled(false)
+
+	if p := s.Properties(&child); p.HasAttributes {
+		t.Error("All states must be checked")
+	}
+
+	child.HandleMessage(Message{Handler: child.Handler(), Type: WmMouseEnter})
+
+	if p := s.Properties(&child); !p.HasAttributes {
+		t.Error("Disabled view under mouse must match")
+	}
+
+	select {
+	case msg := <-*appConfig.Message.Channel():
+		if msg.Type != WmInvalidate {
+			t.Errorf("View must be repaint when mouse enter. Found %+v", msg)
+		}
+	default:
+		t.Error("View must be repaint when mouse enter")
+	}
+}
+
+func TestStylesheet_view_style(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	theme := DefaultTheme()
+	theme.Stylesheet = parseTestStylesheet(t, "Desktop { fg: red; } View { bg: green; }")
+
+	d := NewDesktop("desktop", appConfig.Message, NewMemoryCanvas(10, 10))
+	d.SetTheme(theme)
+
+	_, bg, _ := theme.Style(RoleDesktop).Decompose()
+
+	if d.GetStyleOf(&d, RoleDesktop) != tcell.StyleDefault.Foreground(tcell.ColorRed).Background(bg) {
+		t.Error("Desktop must use stylesheet of theme")
+	}
+
+	v := NewView("view", appConfig.Message, d.ClientCanvas())
+	v.SetParent(&d)
+	v.SetBackgroundColor(tcell.ColorYellow)
+
+	if _, bg, _ := v.GetStyle(RoleView).Decompose(); bg != tcell.ColorYellow {
+		t.Error("Custom color must win on stylesheet")
+	}
+}
+
+func TestStylesheet_application_load_and_watch(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	dir, e := ioutil.TempDir("", "govision")
+
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "style.css")
+
+	if e := ioutil.WriteFile(filename, []byte("View { fg: red; }"), 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := app.LoadStylesheet(filename); e != nil {
+		t.Fatalf("Cannot load stylesheet: %s", e)
+	}
+
+	msg := <-*appConfig.Message.Channel()
+
+	if msg.Type != WmThemeChanged || msg.Value.(*Theme).Stylesheet != app.Stylesheet() {
+		t.Errorf("Theme with stylesheet must be sent. Found %+v", msg)
+	}
+
+	// Draw message.
+	<-*appConfig.Message.Channel()
+
+	errors := make(chan error, 1)
+
+	stop := app.WatchStylesheet(filename, 5*time.Millisecond, func(e error) {
+		errors <- e
+	})
+	defer stop()
+
+	if e := ioutil.WriteFile(filename, []byte("View { fg: blue; bg: red; }"), 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	select {
+	case msg = <-*appConfig.Message.Channel():
+		if msg.Type != WmStylesheetChanged || msg.Handler != ApplicationHandler() {
+			t.Errorf("Stylesheet changed message must be sent. Found %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stylesheet is not reloaded")
+	}
+
+	if e := ioutil.WriteFile(filename, []byte("View { fg: blue }}"), 0644); e != nil {
+		t.Fatal(e)
+	}
+
+	select {
+	case e := <-errors:
+		if !strings.Contains(e.Error(), "'{' expected") {
+			t.Errorf("Wrong error %s", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Syntax error is not reported")
+	}
+}
@@ -26,6 +26,24 @@ type TCanvas interface {
 	PrintChar(x int, y int, char rune)
 	// Print char with brush.
 	PrintCharWithBrush(x int, y int, char rune, brush tcell.Style)
+	// Print char and his combining runes (accent...) with brush.
+	PrintCellWithBrush(x int, y int, char rune, combc []rune, brush tcell.Style)
+	// Print string with current brush property. Return width in cells.
+	PrintString(x int, y int, text string) int
+	// Print string with brush. Return width in cells.
+	PrintStringWithBrush(x int, y int, text string, brush tcell.Style) int
+	// Return width in cells of string.
+	MeasureString(text string) int
+	// Read cell.
+	GetCell(x int, y int) Cell
+	// Draw horizontal line with current brush. Junctions are join.
+	DrawHLine(x int, y int, length int, style LineStyle)
+	// Draw vertical line with current brush. Junctions are join.
+	DrawVLine(x int, y int, length int, style LineStyle)
+	// Draw rectangle border with current brush. Junctions are join.
+	DrawRect(r Rect, style LineStyle)
+	// Draw rectangle border and fill inside with current brush.
+	DrawFrame(r Rect, style LineStyle)
 	// Update position.
 	UpdateBounds(r Rect)
 	// Fill zone of canvas.
@@ -0,0 +1,120 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"unicode"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// Ellipsis is character added at end of truncated string.
+const Ellipsis = '…'
+
+// Zero width joiner (emoji sequence).
+const zeroWidthJoiner = '\u200d'
+
+// Grapheme is a character as user see it: a main rune, combining runes
+// (accent, variation selector...) and width in cells (1 or 2).
+type Grapheme struct {
+	Char      rune
+	Combining []rune
+	Width     int
+}
+
+// SplitGraphemes split text in grapheme clusters. Control characters are
+// ignored.
+func SplitGraphemes(text string) []Grapheme {
+	graphemes := make([]Grapheme, 0, len(text))
+	joinNext := false
+
+	for _, r := range text {
+		if unicode.IsControl(r) {
+			continue
+		}
+
+		width := runewidth.RuneWidth(r)
+		last := len(graphemes) - 1
+
+		if last >= 0 && (width == 0 || joinNext) {
+			graphemes[last].Combining = append(graphemes[last].Combining, r)
+		} else {
+			graphemes = append(graphemes, Grapheme{
+				Char:  r,
+				Width: MaxInt(width, 1),
+			})
+		}
+
+		joinNext = r == zeroWidthJoiner
+	}
+
+	return graphemes
+}
+
+// MeasureString return width in cells of text.
+func MeasureString(text string) int {
+	width := 0
+
+	for _, g := range SplitGraphemes(text) {
+		width += g.Width
+	}
+
+	return width
+}
+
+// TruncateString cut text to `width` cells. If text is truncated, last
+// character is replaced by Ellipsis.
+func TruncateString(text string, width int) string {
+	if MeasureString(text) <= width {
+		return text
+	}
+
+	if width <= 0 {
+		return ""
+	}
+
+	result := make([]rune, 0, len(text))
+	current := 0
+
+	for _, g := range SplitGraphemes(text) {
+		// Keep one cell for ellipsis.
+		if current+g.Width > width-1 {
+			break
+		}
+
+		result = append(result, g.Char)
+		result = append(result, g.Combining...)
+		current += g.Width
+	}
+
+	return string(append(result, Ellipsis))
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+// Print text on canvas, return width in cells of printed text.
+func printString(c TCanvas, x int, y int, text string, brush tcell.Style) int {
+	start := x
+
+	for _, g := range SplitGraphemes(text) {
+		c.PrintCellWithBrush(x, y, g.Char, g.Combining, brush)
+
+		x += g.Width
+	}
+
+	return x - start
+}
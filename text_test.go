@@ -0,0 +1,102 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestText_MeasureString(t *testing.T) {
+	tests := map[string]int{
+		"":          0,
+		"Hello":     5,
+		"日本語":       6,
+		"e\u0301té": 3,
+		"a\tb":      2,
+	}
+
+	for text, width := range tests {
+		if w := MeasureString(text); w != width {
+			t.Errorf("Width of '%s' must be %d. Found %d", text, width, w)
+		}
+	}
+}
+
+func TestText_SplitGraphemes_combining(t *testing.T) {
+	g := SplitGraphemes("e\u0301x")
+
+	if len(g) != 2 {
+		t.Fatalf("Must have 2 graphemes. Found %+v", g)
+	}
+
+	if g[0].Char != 'e' || len(g[0].Combining) != 1 || g[0].Combining[0] != '\u0301' {
+		t.Errorf("Wrong first grapheme %+v", g[0])
+	}
+}
+
+func TestText_TruncateString(t *testing.T) {
+	tests := []struct {
+		text   string
+		width  int
+		result string
+	}{
+		{"Hello", 5, "Hello"},
+		{"Hello", 4, "Hel…"},
+		{"Hello", 0, ""},
+		{"日本語", 4, "日…"},
+		{"e\u0301te\u0301", 2, "e\u0301…"},
+	}
+
+	for _, test := range tests {
+		if r := TruncateString(test.text, test.width); r != test.result {
+			t.Errorf("Truncate '%s' to %d must be '%s'. Found '%s'", test.text, test.width, test.result, r)
+		}
+	}
+}
+
+func TestText_PrintString_wide_and_combining(t *testing.T) {
+	m := NewMemoryCanvas(10, 1)
+
+	st := tcell.StyleDefault.Foreground(tcell.ColorRed)
+
+	m.SetBrush(st)
+
+	if w := m.PrintString(1, 0, "日e\u0301"); w != 3 {
+		t.Errorf("Printed width must be 3. Found %d", w)
+	}
+
+	checkMemoryCell(m, 1, 0, '日', st, t)
+	checkMemoryCell(m, 3, 0, 'e', st, t)
+
+	if c := m.GetCell(3, 0); len(c.Combining) != 1 || c.Combining[0] != '\u0301' {
+		t.Errorf("Combining rune must be kept. Found %+v", c)
+	}
+
+	sub := m.CreateCanvasFrom(Rect{X: 5, Y: 0, Width: 2, Height: 1})
+
+	// Clipped by sub canvas.
+	if w := sub.PrintStringWithBrush(0, 0, "abc", tcell.StyleDefault); w != 3 {
+		t.Errorf("Printed width must be 3. Found %d", w)
+	}
+
+	checkMemoryCell(m, 6, 0, 'b', tcell.StyleDefault, t)
+	checkMemoryCell(m, 7, 0, ' ', tcell.StyleDefault, t)
+
+	if sub.MeasureString("日") != 2 {
+		t.Error("Width must be 2")
+	}
+}
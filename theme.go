@@ -0,0 +1,274 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// StyleRole is name of a style in theme.
+type StyleRole string
+
+const (
+	// RoleDesktop desktop under windows.
+	RoleDesktop StyleRole = "desktop"
+	// RoleView default view.
+	RoleView StyleRole = "view"
+	// RoleWindowFrameActive border of active window.
+	RoleWindowFrameActive StyleRole = "window.frame.active"
+	// RoleWindowFrameInactive border of inactive window.
+	RoleWindowFrameInactive StyleRole = "window.frame.inactive"
+	// RoleWindowCaption caption of window.
+	RoleWindowCaption StyleRole = "window.caption"
+	// RoleWindowClose close button of window.
+	RoleWindowClose StyleRole = "window.close"
+	// RoleWindowClient inside of window.
+	RoleWindowClient StyleRole = "window.client"
+	// RoleLabel static text.
+	RoleLabel StyleRole = "label"
+	// RoleButton button.
+	RoleButton StyleRole = "button"
+	// RoleButtonFocused button with focus.
+	RoleButtonFocused StyleRole = "button.focused"
+	// RoleButtonHover button under mouse.
+	RoleButtonHover StyleRole = "button.hover"
+	// RoleButtonDisabled disabled button.
+	RoleButtonDisabled StyleRole = "button.disabled"
+	// RoleButtonShadow shadow of button.
+	RoleButtonShadow StyleRole = "button.shadow"
+	// RoleCluster check boxes and radio buttons.
+	RoleCluster StyleRole = "cluster"
+	// RoleClusterFocused check box or radio button with focus.
+	RoleClusterFocused StyleRole = "cluster.focused"
+	// RoleInput text input.
+	RoleInput StyleRole = "input"
+	// RoleInputSelection selected text in input.
+	RoleInputSelection StyleRole = "input.selection"
+	// RoleList items of list.
+	RoleList StyleRole = "list"
+	// RoleListFocused current item of list with focus.
+	RoleListFocused StyleRole = "list.focused"
+	// RoleSelection selected item.
+	RoleSelection StyleRole = "selection"
+	// RoleDisabled disabled control.
+	RoleDisabled StyleRole = "disabled"
+	// RoleScrollBar scroll bar.
+	RoleScrollBar StyleRole = "scrollbar"
+	// RoleMenu menu bar and menu.
+	RoleMenu StyleRole = "menu"
+	// RoleMenuSelected selected menu item.
+	RoleMenuSelected StyleRole = "menu.selected"
+	// RoleMenuDisabled disabled menu item.
+	RoleMenuDisabled StyleRole = "menu.disabled"
+	// RoleMenuShortcut shortcut letter of menu item.
+	RoleMenuShortcut StyleRole = "menu.shortcut"
+	// RoleShadow shadow of window and popup.
+	RoleShadow StyleRole = "shadow"
+)
+
+// Theme used by views without theme.
+var defaultTheme = DefaultTheme()
+
+// Theme is a set of styles by role.
+type Theme struct {
+	// Name of theme.
+	Name string
+	// Stylesheet change style of components. Can be nil.
+	Stylesheet *Stylesheet
+
+	styles map[StyleRole]tcell.Style
+}
+
+// Style return style of role. If role is not in theme, view style is return.
+func (t *Theme) Style(role StyleRole) tcell.Style {
+	if st, ok := t.styles[role]; ok {
+		return st
+	}
+
+	if st, ok := t.styles[RoleView]; ok {
+		return st
+	}
+
+	return tcell.StyleDefault
+}
+
+// StyleOf return style of role for component: style of theme changed by
+// stylesheet.
+func (t *Theme) StyleOf(c TComponent, role StyleRole) tcell.Style {
+	st := t.Style(role)
+
+	if t.Stylesheet != nil {
+		st = t.Stylesheet.Properties(c).Apply(st)
+	}
+
+	return st
+}
+
+// SetStyle set style of role.
+func (t *Theme) SetStyle(role StyleRole, st tcell.Style) {
+	t.styles[role] = st
+}
+
+// Roles return all roles of theme.
+func (t *Theme) Roles() []StyleRole {
+	roles := make([]StyleRole, 0, len(t.styles))
+
+	for r := range t.styles {
+		roles = append(roles, r)
+	}
+
+	return roles
+}
+
+// Copy create a new theme with same styles.
+func (t *Theme) Copy(name string) *Theme {
+	c := NewTheme(name)
+	c.Stylesheet = t.Stylesheet
+
+	for r, st := range t.styles {
+		c.styles[r] = st
+	}
+
+	return c
+}
+
+//------------------------------------------------------------------------------
+// Built-in themes.
+
+// DefaultTheme is gray windows on black desktop.
+func DefaultTheme() *Theme {
+	return newThemeFromColors("default", map[StyleRole][2]tcell.Color{
+		RoleDesktop:             {tcell.ColorWhite, tcell.ColorBlack},
+		RoleView:                {tcell.ColorWhite, tcell.ColorBlack},
+		RoleWindowFrameActive:   {tcell.ColorWhite, tcell.ColorGray},
+		RoleWindowFrameInactive: {tcell.ColorSilver, tcell.ColorGray},
+		RoleWindowCaption:       {tcell.ColorWhite, tcell.ColorGray},
+		RoleWindowClose:         {tcell.ColorWhite, tcell.ColorGray},
+		RoleWindowClient:        {tcell.ColorWhite, tcell.ColorGray},
+		RoleLabel:               {tcell.ColorWhite, tcell.ColorGray},
+		RoleButton:              {tcell.ColorBlack, tcell.ColorSilver},
+		RoleButtonFocused:       {tcell.ColorWhite, tcell.ColorTeal},
+		RoleButtonHover:         {tcell.ColorBlack, tcell.ColorWhite},
+		RoleButtonDisabled:      {tcell.ColorGray, tcell.ColorSilver},
+		RoleButtonShadow:        {tcell.ColorBlack, tcell.ColorGray},
+		RoleCluster:             {tcell.ColorWhite, tcell.ColorGray},
+		RoleClusterFocused:      {tcell.ColorWhite, tcell.ColorTeal},
+		RoleInput:               {tcell.ColorWhite, tcell.ColorBlack},
+		RoleInputSelection:      {tcell.ColorBlack, tcell.ColorSilver},
+		RoleList:                {tcell.ColorWhite, tcell.ColorBlack},
+		RoleListFocused:         {tcell.ColorWhite, tcell.ColorTeal},
+		RoleSelection:           {tcell.ColorBlack, tcell.ColorSilver},
+		RoleDisabled:            {tcell.ColorSilver, tcell.ColorGray},
+		RoleScrollBar:           {tcell.ColorSilver, tcell.ColorBlack},
+		RoleMenu:                {tcell.ColorBlack, tcell.ColorSilver},
+		RoleMenuSelected:        {tcell.ColorWhite, tcell.ColorTeal},
+		RoleMenuDisabled:        {tcell.ColorGray, tcell.ColorSilver},
+		RoleMenuShortcut:        {tcell.ColorMaroon, tcell.ColorSilver},
+		RoleShadow:              {tcell.ColorGray, tcell.ColorBlack},
+	})
+}
+
+// ClassicBlueTheme is colors of TurboVision: blue windows on gray desktop.
+func ClassicBlueTheme() *Theme {
+	return newThemeFromColors("classic-blue", map[StyleRole][2]tcell.Color{
+		RoleDesktop:             {tcell.ColorNavy, tcell.ColorSilver},
+		RoleView:                {tcell.ColorYellow, tcell.ColorNavy},
+		RoleWindowFrameActive:   {tcell.ColorWhite, tcell.ColorNavy},
+		RoleWindowFrameInactive: {tcell.ColorSilver, tcell.ColorNavy},
+		RoleWindowCaption:       {tcell.ColorWhite, tcell.ColorNavy},
+		RoleWindowClose:         {tcell.ColorLime, tcell.ColorNavy},
+		RoleWindowClient:        {tcell.ColorYellow, tcell.ColorNavy},
+		RoleLabel:               {tcell.ColorYellow, tcell.ColorNavy},
+		RoleButton:              {tcell.ColorBlack, tcell.ColorGreen},
+		RoleButtonFocused:       {tcell.ColorWhite, tcell.ColorGreen},
+		RoleButtonHover:         {tcell.ColorYellow, tcell.ColorGreen},
+		RoleButtonDisabled:      {tcell.ColorGray, tcell.ColorGreen},
+		RoleButtonShadow:        {tcell.ColorBlack, tcell.ColorNavy},
+		RoleCluster:             {tcell.ColorBlack, tcell.ColorTeal},
+		RoleClusterFocused:      {tcell.ColorWhite, tcell.ColorTeal},
+		RoleInput:               {tcell.ColorWhite, tcell.ColorNavy},
+		RoleInputSelection:      {tcell.ColorWhite, tcell.ColorGreen},
+		RoleList:                {tcell.ColorBlack, tcell.ColorTeal},
+		RoleListFocused:         {tcell.ColorYellow, tcell.ColorGreen},
+		RoleSelection:           {tcell.ColorWhite, tcell.ColorGreen},
+		RoleDisabled:            {tcell.ColorGray, tcell.ColorNavy},
+		RoleScrollBar:           {tcell.ColorNavy, tcell.ColorTeal},
+		RoleMenu:                {tcell.ColorBlack, tcell.ColorSilver},
+		RoleMenuSelected:        {tcell.ColorBlack, tcell.ColorGreen},
+		RoleMenuDisabled:        {tcell.ColorGray, tcell.ColorSilver},
+		RoleMenuShortcut:        {tcell.ColorMaroon, tcell.ColorSilver},
+		RoleShadow:              {tcell.ColorGray, tcell.ColorBlack},
+	})
+}
+
+// MonochromeTheme use only black, white and attributes (terminal without
+// color).
+func MonochromeTheme() *Theme {
+	normal := tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorBlack)
+	bright := normal.Foreground(tcell.ColorWhite).Bold(true)
+	reverse := normal.Reverse(true)
+	dim := normal.Dim(true)
+
+	t := NewTheme("monochrome")
+
+	for _, r := range []StyleRole{RoleDesktop, RoleView, RoleWindowFrameInactive, RoleWindowClient, RoleLabel,
+		RoleCluster, RoleInput, RoleList, RoleScrollBar} {
+		t.SetStyle(r, normal)
+	}
+
+	for _, r := range []StyleRole{RoleWindowFrameActive, RoleWindowCaption, RoleWindowClose, RoleClusterFocused,
+		RoleMenuShortcut} {
+		t.SetStyle(r, bright)
+	}
+
+	for _, r := range []StyleRole{RoleButton, RoleInputSelection, RoleSelection, RoleMenuSelected} {
+		t.SetStyle(r, reverse)
+	}
+
+	for _, r := range []StyleRole{RoleButtonDisabled, RoleDisabled, RoleMenuDisabled, RoleShadow, RoleButtonShadow} {
+		t.SetStyle(r, dim)
+	}
+
+	t.SetStyle(RoleButtonFocused, reverse.Bold(true))
+	t.SetStyle(RoleButtonHover, reverse.Underline(true))
+	t.SetStyle(RoleListFocused, reverse.Bold(true))
+	t.SetStyle(RoleMenu, normal)
+
+	return t
+}
+
+//------------------------------------------------------------------------------
+// Internal functions
+
+func newThemeFromColors(name string, colors map[StyleRole][2]tcell.Color) *Theme {
+	t := NewTheme(name)
+
+	for r, c := range colors {
+		t.styles[r] = tcell.StyleDefault.Foreground(c[0]).Background(c[1])
+	}
+
+	return t
+}
+
+//------------------------------------------------------------------------------
+// Constructor.
+
+// NewTheme create an empty theme.
+func NewTheme(name string) *Theme {
+	return &Theme{
+		Name:   name,
+		styles: make(map[StyleRole]tcell.Style),
+	}
+}
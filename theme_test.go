@@ -0,0 +1,179 @@
+package base
+
+// Copyright 2020 The GoVision Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use file except in compliance with the License.
+// You may obtain a copy of the license at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+var allRoles = []StyleRole{
+	RoleDesktop, RoleView, RoleWindowFrameActive, RoleWindowFrameInactive, RoleWindowCaption,
+	RoleWindowClose, RoleWindowClient, RoleLabel, RoleButton, RoleButtonFocused, RoleButtonHover,
+	RoleButtonDisabled, RoleButtonShadow, RoleCluster, RoleClusterFocused, RoleInput, RoleInputSelection, RoleList, RoleListFocused, RoleSelection, RoleDisabled,
+	RoleScrollBar, RoleMenu, RoleMenuSelected, RoleMenuDisabled, RoleMenuShortcut, RoleShadow,
+}
+
+func TestTheme_builtin_themes_have_all_roles(t *testing.T) {
+	for _, theme := range []*Theme{DefaultTheme(), ClassicBlueTheme(), MonochromeTheme()} {
+		if len(theme.Roles()) != len(allRoles) {
+			t.Errorf("Theme %s must have %d roles. Found %d", theme.Name, len(allRoles), len(theme.Roles()))
+		}
+
+		for _, r := range allRoles {
+			if _, ok := theme.styles[r]; !ok {
+				t.Errorf("Theme %s must have role %s", theme.Name, r)
+			}
+		}
+	}
+}
+
+func TestTheme_Style_fallback(t *testing.T) {
+	theme := NewTheme("test")
+
+	if theme.Style(RoleButton) != tcell.StyleDefault {
+		t.Error("Empty theme must return default style")
+	}
+
+	st := tcell.StyleDefault.Foreground(tcell.ColorRed)
+	theme.SetStyle(RoleView, st)
+
+	if theme.Style(RoleButton) != st {
+		t.Error("Unknown role must return view style")
+	}
+
+	c := theme.Copy("copy")
+	c.SetStyle(RoleView, tcell.StyleDefault)
+
+	if c.Name != "copy" || theme.Style(RoleView) != st {
+		t.Error("Copy must not change original theme")
+	}
+}
+
+func TestTheme_view_style(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	parent := NewView("parent", appConfig.Message, NewMemoryCanvas(10, 10))
+	child := NewView("child", appConfig.Message, parent.ClientCanvas())
+	child.SetParent(&parent)
+
+	if child.GetTheme() != defaultTheme {
+		t.Error("View without theme must use default theme")
+	}
+
+	theme := ClassicBlueTheme()
+	parent.SetTheme(theme)
+
+	if child.GetTheme() != theme {
+		t.Error("View without theme must use theme of parent")
+	}
+
+	if child.GetStyle(RoleLabel) != theme.Style(RoleLabel) {
+		t.Error("View must use style of theme")
+	}
+
+	child.SetBackgroundColor(tcell.ColorRed)
+
+	fg, _, _ := theme.Style(RoleLabel).Decompose()
+
+	if child.GetStyle(RoleLabel) != tcell.StyleDefault.Foreground(fg).Background(tcell.ColorRed) {
+		t.Error("Custom background must replace only background of theme")
+	}
+}
+
+func TestTheme_application_SetTheme(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+
+	app := NewApplication(appConfig)
+
+	w := NewView("window", appConfig.Message, app.Canvas())
+	child := NewView("child", appConfig.Message, w.ClientCanvas())
+	child.SetParent(&w)
+	child.SetTheme(MonochromeTheme())
+	w.AddChild(&child)
+
+	app.AddWindow(&w)
+
+	if app.Theme() != defaultTheme || app.Desktop().GetTheme() != defaultTheme {
+		t.Error("Application must use default theme")
+	}
+
+	theme := ClassicBlueTheme()
+
+	app.SetTheme(theme)
+
+	for _, msgType := range []uint{WmThemeChanged, WmDraw} {
+		msg := <-*appConfig.Message.Channel()
+
+		if msg.Type != msgType || msg.Handler != BroadcastHandler() {
+			t.Errorf("Wrong message %+v", msg)
+		}
+
+		app.manageMessage(msg)
+	}
+
+	if w.GetTheme() != theme || app.Desktop().GetTheme() != theme {
+		t.Error("All views must receive new theme")
+	}
+
+	if child.GetTheme().Name != MonochromeTheme().Name {
+		t.Error("Theme set on view must be kept")
+	}
+
+	// New window receive theme.
+	w2 := NewView("window2", appConfig.Message, app.Canvas())
+	app.AddWindow(&w2)
+
+	if w2.GetTheme() != theme {
+		t.Error("New window must use application theme")
+	}
+
+	// Theme set on window is kept too.
+	w.SetTheme(MonochromeTheme())
+
+	app.SetTheme(DefaultTheme())
+
+	app.manageMessage(<-*appConfig.Message.Channel())
+
+	if w.GetTheme().Name != MonochromeTheme().Name || w2.GetTheme().Name != DefaultTheme().Name {
+		t.Error("Only theme given by application must be replaced")
+	}
+}
+
+func TestTheme_application_ScreenStyle(t *testing.T) {
+	appConfig := CreateTestApplicationConfig()
+	appConfig.ScreenStyle.ForegroundColor = tcell.ColorYellow
+	appConfig.ScreenStyle.BackgroundColor = tcell.ColorNavy
+
+	app := NewApplication(appConfig)
+
+	st := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorNavy)
+
+	if app.Desktop().GetStyle(RoleDesktop) != st {
+		t.Error("Desktop must use screen style")
+	}
+
+	if app.Theme().Style(RoleView) != defaultTheme.Style(RoleView) {
+		t.Error("Other roles must use default theme")
+	}
+
+	// Screen style is not set.
+	app = NewApplication(ApplicationConfig{Screen: appConfig.Screen, Message: appConfig.Message})
+
+	if app.Theme() != defaultTheme {
+		t.Error("Application must use default theme")
+	}
+}
@@ -39,6 +39,9 @@ type TView interface {
 	// Visible component.
 	SetVisible(bool)
 	GetVisible() bool
+	// Top-level component stay above normal windows.
+	SetStayOnTop(bool)
+	GetStayOnTop() bool
 	// Client size.
 	GetClientBounds() Rect
 	// Color of component.
@@ -46,8 +49,17 @@ type TView interface {
 	SetBackgroundColor(tcell.Color)
 	GetForegroundColor() tcell.Color
 	SetForegroundColor(tcell.Color)
+	// Text attributes of component.
+	GetFont() Font
+	SetFont(Font)
+	// Theme of component.
+	SetTheme(*Theme)
+	GetTheme() *Theme
 	// Draw component
 	Draw()
+	// Ask application to repaint component (or only a part).
+	Invalidate()
+	InvalidateRect(Rect)
 	// Canvas of view.
 	Canvas() TCanvas
 	// Client canvas of view.
@@ -25,9 +25,22 @@ type View struct {
 	canvas          TCanvas
 	bounds          Rect
 	focused         bool
+	hover           bool
 	visible         bool
+	stayOnTop       bool
 	backgroundColor tcell.Color
 	foregroundColor tcell.Color
+	// Colors set by user replace colors of theme.
+	customBackground bool
+	customForeground bool
+	// Text attributes.
+	font       Font
+	customFont bool
+	// Theme of view. If nil, theme of parent is used.
+	theme *Theme
+	// Theme was given by application and is replaced when theme of
+	// application change.
+	applicationTheme bool
 	// To overide draw for custom draw for example.
 	onDraw OnDraw
 	// To overide behavior.
@@ -170,7 +183,13 @@ func (v *View) GetFocused() bool {
 
 // SetVisible if component is visible.
 func (v *View) SetVisible(s bool) {
+	if v.visible == s {
+		return
+	}
+
 	v.visible = s
+	// Repaint area of view: view is draw or what is under view is draw.
+	v.Invalidate()
 }
 
 // GetVisible if component is visible.
@@ -178,6 +197,16 @@ func (v *View) GetVisible() bool {
 	return v.visible
 }
 
+// SetStayOnTop if top-level component must stay above normal windows.
+func (v *View) SetStayOnTop(s bool) {
+	v.stayOnTop = s
+}
+
+// GetStayOnTop return true if top-level component stay above normal windows.
+func (v *View) GetStayOnTop() bool {
+	return v.stayOnTop
+}
+
 // GetClientBounds return client size.
 func (v *View) GetClientBounds() Rect {
 	return Rect{
@@ -196,6 +225,7 @@ func (v *View) GetBackgroundColor() tcell.Color {
 // SetBackgroundColor change background color.
 func (v *View) SetBackgroundColor(c tcell.Color) {
 	v.backgroundColor = c
+	v.customBackground = true
 }
 
 // GetForegroundColor return text color.
@@ -206,6 +236,69 @@ func (v *View) GetForegroundColor() tcell.Color {
 // SetForegroundColor change text color.
 func (v *View) SetForegroundColor(c tcell.Color) {
 	v.foregroundColor = c
+	v.customForeground = true
+}
+
+// GetFont return text attributes set with SetFont.
+func (v *View) GetFont() Font {
+	return v.font
+}
+
+// SetFont change text attributes.
+func (v *View) SetFont(f Font) {
+	v.font = f
+	v.customFont = true
+}
+
+// SetTheme set theme of view and children without theme. It is kept when
+// theme of application change.
+func (v *View) SetTheme(t *Theme) {
+	v.theme = t
+	v.applicationTheme = false
+}
+
+// GetTheme return theme of view, of his parent or default theme.
+func (v *View) GetTheme() *Theme {
+	if v.theme != nil {
+		return v.theme
+	}
+
+	if p, ok := v.GetParent().(TView); ok && p != nil {
+		return p.GetTheme()
+	}
+
+	return defaultTheme
+}
+
+// GetStyle return style of role in theme. Colors set with SetForegroundColor
+// and SetBackgroundColor and font set with SetFont replace those of theme.
+func (v *View) GetStyle(role StyleRole) tcell.Style {
+	return v.GetStyleOf(v, role)
+}
+
+// GetStyleOf is GetStyle for component that embed or wrap view (stylesheet
+// match type of `c`).
+func (v *View) GetStyleOf(c TComponent, role StyleRole) tcell.Style {
+	st := v.GetTheme().StyleOf(c, role)
+
+	if v.customForeground {
+		st = st.Foreground(v.foregroundColor)
+	}
+
+	if v.customBackground {
+		st = st.Background(v.backgroundColor)
+	}
+
+	if v.customFont {
+		st = v.font.Apply(st)
+	}
+
+	return st
+}
+
+// GetHover return true if mouse is over view.
+func (v *View) GetHover() bool {
+	return v.hover
 }
 
 // Draw the view.
@@ -214,9 +307,7 @@ func (v *View) Draw() {
 		return
 	}
 
-	v.canvas.SetBrush(tcell.StyleDefault.
-		Foreground(v.foregroundColor).
-		Background(v.backgroundColor))
+	v.canvas.SetBrush(v.GetStyle(RoleView))
 
 	bounds := v.GetBounds()
 	bounds.X = 0
@@ -225,6 +316,31 @@ func (v *View) Draw() {
 	v.canvas.Fill(bounds)
 }
 
+// Invalidate ask application to repaint view at next frame.
+func (v *View) Invalidate() {
+	v.InvalidateRect(Rect{
+		X:      0,
+		Y:      0,
+		Width:  v.bounds.Width,
+		Height: v.bounds.Height,
+	})
+}
+
+// InvalidateRect ask application to repaint a part of view at next frame.
+// `r` is relative to view bounds.
+func (v *View) InvalidateRect(r Rect) {
+	absoluteBounds := AbsoluteBounds(v)
+
+	r.X += absoluteBounds.X
+	r.Y += absoluteBounds.Y
+
+	r = Intersect(r, absoluteBounds)
+
+	if !IsEmpty(r) {
+		v.component.message.Send(BuildInvalidateMessage(r))
+	}
+}
+
 // Canvas of view.
 func (v *View) Canvas() TCanvas {
 	return v.canvas
@@ -275,17 +391,38 @@ func (v *View) manageMyMessage(msg Message) {
 			v.onChangeBounds(msg.Value.(Rect))
 		}
 
+		// Repaint old area and new area.
+		v.Invalidate()
 		v.SetBounds(msg.Value.(Rect))
-		// Redraw all components cause maybe overide a component with Zorder
-		v.component.message.Send(BuildDrawMessage(BroadcastHandler()))
+		v.Invalidate()
 	case WmActivate:
 		if v.onActivate != nil {
 			v.onActivate(msg.Value == WaActive)
 		}
 
 		v.SetFocused(msg.Value == WaActive)
-	default:
+	case WmThemeChanged:
+		// Theme set with SetTheme is kept. Children without theme use theme
+		// of parent.
+		if v.applicationTheme || (v.theme == nil && v.GetParent() == nil) {
+			v.theme = msg.Value.(*Theme)
+			v.applicationTheme = true
+		}
+	case WmMouseEnter, WmMouseLeave:
+		v.hover = msg.Type == WmMouseEnter
+		// Style can change with hover.
+		v.Invalidate()
 		v.component.HandleMessage(msg)
+	case WmMouseWheel:
+		// View can't scroll, parent maybe.
+		if f := v.GetOnReceiveMessage(); f == nil || !f(v, msg) {
+			SendWheelToParent(v, msg.Value.(*tcell.EventMouse))
+		}
+	default:
+		// Broadcast is sent to component by HandleMessage.
+		if msg.Handler != BroadcastHandler() {
+			v.component.HandleMessage(msg)
+		}
 	}
 }
 